@@ -0,0 +1 @@
+data "mailcow_alias_domains" "example" {}
\ No newline at end of file
@@ -0,0 +1 @@
+data "mailcow_all_resources" "example" {}
\ No newline at end of file
@@ -0,0 +1,3 @@
+data "mailcow_spam_policies" "example" {
+  domain = "mailcow.tld"
+}
\ No newline at end of file
@@ -2,23 +2,32 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Client struct {
-	HostURL    string
-	HttpClient *http.Client
-	apiKey     string
+	HostURL      string
+	HttpClient   *http.Client
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	apiKey       string
+	limiter      *limiter
 }
 
 func NewClient(host, apiKey *string) (*Client, error) {
 	c := Client{
-		HttpClient: &http.Client{},
+		HttpClient:   &http.Client{},
+		MaxRetries:   DefaultMaxRetries,
+		RetryWaitMin: DefaultRetryWaitMin,
+		RetryWaitMax: DefaultRetryWaitMax,
 	}
 
 	if host != nil {
@@ -32,41 +41,177 @@ func NewClient(host, apiKey *string) (*Client, error) {
 	return &c, nil
 }
 
+// SetRateLimit limits the client to maxConcurrent requests in flight and
+// requestsPerSecond requests per second across all copies of the client.
+// Zero disables either limit.
+func (c *Client) SetRateLimit(maxConcurrent int, requestsPerSecond float64) {
+	c.limiter = newLimiter(maxConcurrent, requestsPerSecond)
+}
+
+// DoRequest sends req and returns the response body. Connection errors, 429
+// and 5xx responses are retried up to MaxRetries times with exponential
+// backoff when shouldRetry considers the request safe to repeat. Every attempt
+// waits for the rate limiter configured with SetRateLimit. Waiting stops as
+// soon as the request context is cancelled.
 func (c *Client) DoRequest(req *http.Request) ([]byte, error) {
+	ctx := withLogging(req.Context(), req)
+
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 	req.Header.Set("X-API-Key", c.apiKey)
 
-	res, err := c.HttpClient.Do(req)
+	var requestBody []byte
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			requestBody, _ = ioutil.ReadAll(body)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		if err := c.limiter.acquire(ctx); err != nil {
+			return nil, err
+		}
+
+		logRequest(ctx, req, attempt+1, requestBody)
+		start := time.Now()
+		res, err := c.HttpClient.Do(req)
+
+		var statusCode int
+		var body []byte
+		if err == nil {
+			statusCode = res.StatusCode
+			body, err = ioutil.ReadAll(res.Body)
+			res.Body.Close()
+		}
+		c.limiter.release()
+		logResponse(ctx, statusCode, time.Since(start), body, err)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if attempt < c.MaxRetries && shouldRetry(req, statusCode, err) {
+			wait := backoff(c.RetryWaitMin, c.RetryWaitMax, attempt, res)
+			logRetry(ctx, attempt+1, c.MaxRetries, wait, statusCode, err)
+			if err := sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if statusCode != http.StatusOK {
+			return nil, parseAPIError(statusCode, body)
+		}
+
+		return body, nil
+	}
+}
+
+// post marshals payload as the JSON body of a POST to path and returns the
+// first entry of the mailcow response envelope.
+func (c *Client) post(ctx context.Context, path string, payload interface{}) (*postResponse, error) {
+	url := c.HostURL + path
+
+	data, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
-	defer res.Body.Close()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
 
-	body, err := ioutil.ReadAll(res.Body)
+	res, err := c.DoRequest(req)
 	if err != nil {
 		return nil, err
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+	var responses []postResponse
+	err = json.Unmarshal(res, &responses)
+	if err != nil || len(responses) == 0 {
+		return nil, parseAPIError(http.StatusOK, res)
+	}
+
+	if responses[0].Type != Success {
+		return nil, newAPIError(http.StatusOK, responses[0], res)
 	}
 
-	return body, err
+	return &responses[0], nil
 }
 
-func (c *Client) GetAlias(id int64) (*AliasResponse, error) {
-	url := c.HostURL + "/api/v1/get/alias/" + strconv.FormatInt(id, 10)
+// get decodes the JSON response of a GET to path into target. Mailcow reports
+// some failures, such as access_denied, as an error envelope with status 200.
+func (c *Client) get(ctx context.Context, path string, target interface{}) error {
+	url := c.HostURL + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
 
-	req, _ := http.NewRequest("GET", url, nil)
 	res, err := c.DoRequest(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var item AliasResponse
-	err = json.Unmarshal(res, &item)
+	var response postResponse
+	if json.Unmarshal(res, &response) == nil && (response.Type == Error || response.Type == Danger) {
+		return newAPIError(http.StatusOK, response, res)
+	}
+
+	return json.Unmarshal(res, target)
+}
+
+// getObject is get for endpoints returning a single object. Mailcow answers
+// lookups of missing objects with {} or [] and status 200, so those are
+// reported as a NotFoundError.
+func (c *Client) getObject(ctx context.Context, path string, target interface{}) error {
+	var raw json.RawMessage
+	err := c.get(ctx, path, &raw)
+	if err != nil {
+		return err
+	}
+
+	switch strings.TrimSpace(string(raw)) {
+	case "", "{}", "[]", "null", "false":
+		return &NotFoundError{Path: path}
+	}
+
+	return json.Unmarshal(raw, target)
+}
+
+// getList is get for endpoints returning a list, which mailcow answers with {}
+// instead of [] when the list is empty.
+func (c *Client) getList(ctx context.Context, path string, target interface{}) error {
+	var raw json.RawMessage
+	err := c.get(ctx, path, &raw)
+	if err != nil {
+		return err
+	}
 
+	switch strings.TrimSpace(string(raw)) {
+	case "", "{}", "null", "false":
+		raw = json.RawMessage("[]")
+	}
+
+	return json.Unmarshal(raw, target)
+}
+
+func (c *Client) GetAlias(ctx context.Context, id int64) (*AliasResponse, error) {
+	var item AliasResponse
+	err := c.getObject(ctx, "/api/v1/get/alias/"+strconv.FormatInt(id, 10), &item)
 	if err != nil {
 		return nil, err
 	}
@@ -74,60 +219,187 @@ func (c *Client) GetAlias(id int64) (*AliasResponse, error) {
 	return &item, nil
 }
 
-func (c *Client) GetAllAliases() (*[]AliasResponse, error) {
-	url := c.HostURL + "/api/v1/get/alias/all"
+func (c *Client) GetAllAliases(ctx context.Context) (*[]AliasResponse, error) {
+	var aliases []AliasResponse
+	err := c.getList(ctx, "/api/v1/get/alias/all", &aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	return &aliases, nil
+}
+
+func (c *Client) AddAlias(ctx context.Context, alias AliasRequest) (int64, error) {
+	res, err := c.post(ctx, "/api/v1/add/alias", alias)
+	if err != nil {
+		return 0, err
+	}
 
-	req, _ := http.NewRequest("GET", url, nil)
-	res, err := c.DoRequest(req)
+	// A successful response looks like ["alias_added", "alias@mailcow.tld", "42"]
+	id, ok := responseID(res)
+	if !ok {
+		return 0, fmt.Errorf("unexpected response from mailcow: %s", res.Message)
+	}
+
+	return id, nil
+}
+
+// responseID returns the ID of a new object from the third element of a
+// success message, such as ["alias_added", "alias@mailcow.tld", "42"].
+func responseID(res *postResponse) (int64, bool) {
+	if len(res.Message) < 3 {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(res.Message[2], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (c *Client) EditAlias(ctx context.Context, id int64, alias AliasRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/alias", editRequest{
+		Attr:  alias,
+		Items: []string{strconv.FormatInt(id, 10)},
+	})
+
+	return err
+}
+
+func (c *Client) DeleteAlias(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/alias", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+// AddRelayhost creates a relayhost and returns its ID. Mailcow does not return
+// the ID, so it is looked up as the newest relayhost with the same hostname
+// and username.
+func (c *Client) AddRelayhost(ctx context.Context, relayhost RelayhostRequest) (int64, error) {
+	_, err := c.post(ctx, "/api/v1/add/relayhost", relayhost)
+	if err != nil {
+		return 0, err
+	}
+
+	relayhosts, err := c.GetAllRelayhosts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	for _, item := range *relayhosts {
+		if item.Hostname == relayhost.Hostname && item.Username == relayhost.Username && item.ID > id {
+			id = item.ID
+		}
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("relayhost %s was added but cannot be found", relayhost.Hostname)
+	}
+
+	return id, nil
+}
+
+func (c *Client) GetRelayhost(ctx context.Context, id int64) (*RelayhostResponse, error) {
+	var item RelayhostResponse
+	err := c.getObject(ctx, "/api/v1/get/relayhost/"+strconv.FormatInt(id, 10), &item)
 	if err != nil {
 		return nil, err
 	}
 
-	var aliases []AliasResponse
-	err = json.Unmarshal(res, &aliases)
+	return &item, nil
+}
 
+func (c *Client) GetAllRelayhosts(ctx context.Context) (*[]RelayhostResponse, error) {
+	var relayhosts []RelayhostResponse
+	err := c.getList(ctx, "/api/v1/get/relayhost/all", &relayhosts)
 	if err != nil {
 		return nil, err
 	}
 
-	return &aliases, nil
+	return &relayhosts, nil
 }
 
-func (c *Client) DeleteAlias(id int64) error {
-	url := c.HostURL + "/api/v1/delete/alias"
+func (c *Client) EditRelayhost(ctx context.Context, id int64, relayhost RelayhostRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/relayhost", editRequest{
+		Attr:  relayhost,
+		Items: []string{strconv.FormatInt(id, 10)},
+	})
 
-	data := []byte(`[
-		"` + strconv.FormatInt(id, 10) + `"
-	]`)
+	return err
+}
 
-	req, _ := http.NewRequest("POST", url, bytes.NewBuffer(data))
-	res, err := c.DoRequest(req)
+func (c *Client) DeleteRelayhost(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/relayhost", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+// AddTransport creates a transport map entry and returns its ID, which is
+// looked up by destination as mailcow does not return it.
+func (c *Client) AddTransport(ctx context.Context, transport TransportRequest) (int64, error) {
+	_, err := c.post(ctx, "/api/v1/add/transport", transport)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	var responses []postResponse
-	err = json.Unmarshal(res, &responses)
+	transports, err := c.GetAllTransports(ctx)
+	if err != nil {
+		return 0, err
+	}
 
-	if responses[0].Type != Success {
-		return errors.New(responses[0].Message)
+	var id int64
+	for _, item := range *transports {
+		if item.Destination == transport.Destination && item.ID > id {
+			id = item.ID
+		}
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("transport for %s was added but cannot be found", transport.Destination)
 	}
 
-	return nil
+	return id, nil
 }
 
-func (c *Client) GetDomain(domain string) (*DomainResponse, error) {
-	url := c.HostURL + "/api/v1/get/domain/" + domain
+func (c *Client) GetTransport(ctx context.Context, id int64) (*TransportResponse, error) {
+	var item TransportResponse
+	err := c.getObject(ctx, "/api/v1/get/transport/"+strconv.FormatInt(id, 10), &item)
+	if err != nil {
+		return nil, err
+	}
 
-	req, _ := http.NewRequest("GET", url, nil)
-	res, err := c.DoRequest(req)
+	return &item, nil
+}
+
+func (c *Client) GetAllTransports(ctx context.Context) (*[]TransportResponse, error) {
+	var transports []TransportResponse
+	err := c.getList(ctx, "/api/v1/get/transport/all", &transports)
 	if err != nil {
 		return nil, err
 	}
 
-	var item DomainResponse
-	err = json.Unmarshal(res, &item)
+	return &transports, nil
+}
+
+func (c *Client) EditTransport(ctx context.Context, id int64, transport TransportRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/transport", editRequest{
+		Attr:  transport,
+		Items: []string{strconv.FormatInt(id, 10)},
+	})
+
+	return err
+}
 
+func (c *Client) DeleteTransport(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/transport", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+func (c *Client) GetDKIM(ctx context.Context, domain string) (*DKIMResponse, error) {
+	var item DKIMResponse
+	err := c.getObject(ctx, "/api/v1/get/dkim/"+domain, &item)
 	if err != nil {
 		return nil, err
 	}
@@ -135,18 +407,39 @@ func (c *Client) GetDomain(domain string) (*DomainResponse, error) {
 	return &item, nil
 }
 
-func (c *Client) GetAllDomains() (*[]DomainResponse, error) {
-	url := c.HostURL + "/api/v1/get/domain/all"
+func (c *Client) AddDKIM(ctx context.Context, dkim DKIMRequest) error {
+	_, err := c.post(ctx, "/api/v1/add/dkim", dkim)
 
-	req, _ := http.NewRequest("GET", url, nil)
-	res, err := c.DoRequest(req)
+	return err
+}
+
+// DuplicateDKIM copies the DKIM key of one domain to another, replacing any
+// key the target domain already has.
+func (c *Client) DuplicateDKIM(ctx context.Context, duplicate DKIMDuplicateRequest) error {
+	_, err := c.post(ctx, "/api/v1/add/dkim_duplicate", duplicate)
+
+	return err
+}
+
+func (c *Client) DeleteDKIM(ctx context.Context, domain string) error {
+	_, err := c.post(ctx, "/api/v1/delete/dkim", []string{domain})
+
+	return err
+}
+
+func (c *Client) GetDomain(ctx context.Context, domain string) (*DomainResponse, error) {
+	var item DomainResponse
+	err := c.getObject(ctx, "/api/v1/get/domain/"+domain, &item)
 	if err != nil {
 		return nil, err
 	}
 
-	var domains []DomainResponse
-	err = json.Unmarshal(res, &domains)
+	return &item, nil
+}
 
+func (c *Client) GetAllDomains(ctx context.Context) (*[]DomainResponse, error) {
+	var domains []DomainResponse
+	err := c.getList(ctx, "/api/v1/get/domain/all", &domains)
 	if err != nil {
 		return nil, err
 	}
@@ -154,41 +447,126 @@ func (c *Client) GetAllDomains() (*[]DomainResponse, error) {
 	return &domains, nil
 }
 
-func (c *Client) DeleteDomain(domain string) error {
-	url := c.HostURL + "/api/v1/delete/domain"
+func (c *Client) AddDomain(ctx context.Context, domain DomainRequest) error {
+	_, err := c.post(ctx, "/api/v1/add/domain", domain)
+
+	return err
+}
 
-	data := []byte(`[
-		"` + domain + `"
-	]`)
+func (c *Client) EditDomain(ctx context.Context, domain string, attributes DomainRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/domain", editRequest{
+		Attr:  attributes,
+		Items: []string{domain},
+	})
 
-	req, _ := http.NewRequest("POST", url, bytes.NewBuffer(data))
-	res, err := c.DoRequest(req)
+	return err
+}
+
+func (c *Client) DeleteDomain(ctx context.Context, domain string) error {
+	_, err := c.post(ctx, "/api/v1/delete/domain", []string{domain})
+
+	return err
+}
+
+func (c *Client) GetAliasDomain(ctx context.Context, aliasDomain string) (*AliasDomainResponse, error) {
+	var item AliasDomainResponse
+	err := c.getObject(ctx, "/api/v1/get/alias-domain/"+aliasDomain, &item)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var responses []postResponse
-	err = json.Unmarshal(res, &responses)
+	return &item, nil
+}
 
-	if responses[0].Type != Success {
-		return errors.New(responses[0].Message)
+func (c *Client) GetAllAliasDomains(ctx context.Context) (*[]AliasDomainResponse, error) {
+	var aliasDomains []AliasDomainResponse
+	err := c.getList(ctx, "/api/v1/get/alias-domain/all", &aliasDomains)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &aliasDomains, nil
 }
 
-func (c *Client) GetMailbox(username string) (*MailboxResponse, error) {
-	url := c.HostURL + "/api/v1/get/mailbox/" + username
+func (c *Client) AddAliasDomain(ctx context.Context, aliasDomain AliasDomainRequest) error {
+	_, err := c.post(ctx, "/api/v1/add/alias-domain", aliasDomain)
 
-	req, _ := http.NewRequest("GET", url, nil)
-	res, err := c.DoRequest(req)
+	return err
+}
+
+func (c *Client) EditAliasDomain(ctx context.Context, aliasDomain string, attributes AliasDomainRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/alias-domain", editRequest{
+		Attr:  attributes,
+		Items: []string{aliasDomain},
+	})
+
+	return err
+}
+
+func (c *Client) DeleteAliasDomain(ctx context.Context, aliasDomain string) error {
+	_, err := c.post(ctx, "/api/v1/delete/alias-domain", []string{aliasDomain})
+
+	return err
+}
+
+func (c *Client) GetDomainAdmin(ctx context.Context, username string) (*DomainAdminResponse, error) {
+	var item DomainAdminResponse
+	err := c.getObject(ctx, "/api/v1/get/domain-admin/"+username, &item)
 	if err != nil {
 		return nil, err
 	}
 
-	var item MailboxResponse
-	err = json.Unmarshal(res, &item)
+	return &item, nil
+}
+
+func (c *Client) AddDomainAdmin(ctx context.Context, domainAdmin DomainAdminRequest) error {
+	_, err := c.post(ctx, "/api/v1/add/domain-admin", domainAdmin)
+
+	return err
+}
+
+func (c *Client) EditDomainAdmin(ctx context.Context, username string, domainAdmin DomainAdminRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/domain-admin", editRequest{
+		Attr:  domainAdmin,
+		Items: []string{username},
+	})
+
+	return err
+}
+
+func (c *Client) EditDomainAdminACL(ctx context.Context, username string, acl DomainAdminACLRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/da-acl", editRequest{
+		Attr:  acl,
+		Items: []string{username},
+	})
+
+	return err
+}
+
+func (c *Client) DeleteDomainAdmin(ctx context.Context, username string) error {
+	_, err := c.post(ctx, "/api/v1/delete/domain-admin", []string{username})
 
+	return err
+}
+
+func (c *Client) AddMailbox(ctx context.Context, mailbox MailboxRequest) error {
+	_, err := c.post(ctx, "/api/v1/add/mailbox", mailbox)
+
+	return err
+}
+
+func (c *Client) EditMailbox(ctx context.Context, username string, mailbox MailboxRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/mailbox", editRequest{
+		Attr:  mailbox,
+		Items: []string{username},
+	})
+
+	return err
+}
+
+func (c *Client) GetMailbox(ctx context.Context, username string) (*MailboxResponse, error) {
+	var item MailboxResponse
+	err := c.getObject(ctx, "/api/v1/get/mailbox/"+username, &item)
 	if err != nil {
 		return nil, err
 	}
@@ -196,44 +574,734 @@ func (c *Client) GetMailbox(username string) (*MailboxResponse, error) {
 	return &item, nil
 }
 
-func (c *Client) GetAllMailboxes() (*[]MailboxResponse, error) {
-	url := c.HostURL + "/api/v1/get/mailbox/all"
+func (c *Client) GetAllMailboxes(ctx context.Context) (*[]MailboxResponse, error) {
+	var mailboxes []MailboxResponse
+	err := c.getList(ctx, "/api/v1/get/mailbox/all", &mailboxes)
+	if err != nil {
+		return nil, err
+	}
 
-	req, _ := http.NewRequest("GET", url, nil)
-	res, err := c.DoRequest(req)
+	return &mailboxes, nil
+}
+
+func (c *Client) DeleteMailbox(ctx context.Context, mailbox string) error {
+	_, err := c.post(ctx, "/api/v1/delete/mailbox", []string{mailbox})
+
+	return err
+}
+
+// AddSyncJob creates a sync job and returns its ID, which is looked up as the
+// newest job of the mailbox for the same source account, as mailcow does not
+// return it.
+func (c *Client) AddSyncJob(ctx context.Context, syncJob SyncJobRequest) (int64, error) {
+	_, err := c.post(ctx, "/api/v1/add/syncjob", syncJob)
+	if err != nil {
+		return 0, err
+	}
+
+	syncJobs, err := c.GetAllSyncJobs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	for _, item := range *syncJobs {
+		if item.Username == syncJob.Username && item.Host == syncJob.Host && item.User == syncJob.User && item.ID > id {
+			id = item.ID
+		}
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("sync job for %s was added but cannot be found", syncJob.Username)
+	}
+
+	return id, nil
+}
+
+// GetSyncJob returns a sync job by ID. Mailcow can only list all sync jobs,
+// so the job is picked from that list.
+func (c *Client) GetSyncJob(ctx context.Context, id int64) (*SyncJobResponse, error) {
+	syncJobs, err := c.GetAllSyncJobs(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var mailboxes []MailboxResponse
-	err = json.Unmarshal(res, &mailboxes)
+	for _, item := range *syncJobs {
+		if item.ID == id {
+			return &item, nil
+		}
+	}
+
+	return nil, &NotFoundError{Path: "/api/v1/get/syncjobs/all/no_log"}
+}
 
+func (c *Client) GetAllSyncJobs(ctx context.Context) (*[]SyncJobResponse, error) {
+	var syncJobs []SyncJobResponse
+	err := c.getList(ctx, "/api/v1/get/syncjobs/all/no_log", &syncJobs)
 	if err != nil {
 		return nil, err
 	}
 
-	return &mailboxes, nil
+	return &syncJobs, nil
 }
 
-func (c *Client) DeleteMailbox(mailbox string) error {
-	url := c.HostURL + "/api/v1/delete/mailbox"
+func (c *Client) EditSyncJob(ctx context.Context, id int64, syncJob SyncJobRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/syncjob", editRequest{
+		Attr:  syncJob,
+		Items: []string{strconv.FormatInt(id, 10)},
+	})
 
-	data := []byte(`[
-		"` + mailbox + `"
-	]`)
+	return err
+}
 
-	req, _ := http.NewRequest("POST", url, bytes.NewBuffer(data))
-	res, err := c.DoRequest(req)
+func (c *Client) DeleteSyncJob(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/syncjob", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+// AddDomainPolicy adds an entry to the whitelist or blacklist of a domain and
+// returns its ID, which is looked up as mailcow does not return it.
+func (c *Client) AddDomainPolicy(ctx context.Context, policy DomainPolicyRequest) (int64, error) {
+	_, err := c.post(ctx, "/api/v1/add/domain-policy", policy)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	var responses []postResponse
-	err = json.Unmarshal(res, &responses)
+	policies, err := c.GetDomainPolicies(ctx, policy.Domain, policy.List)
+	if err != nil {
+		return 0, err
+	}
 
-	if responses[0].Type != Success {
-		return errors.New(responses[0].Message)
+	return findSpamPolicy(*policies, policy.From)
+}
+
+// GetDomainPolicies returns the entries of the whitelist ("wl") or blacklist
+// ("bl") of a domain.
+func (c *Client) GetDomainPolicies(ctx context.Context, domain, list string) (*[]SpamPolicyResponse, error) {
+	var policies []SpamPolicyResponse
+	err := c.getList(ctx, "/api/v1/get/policy_"+list+"_domain/"+domain, &policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policies, nil
+}
+
+func (c *Client) DeleteDomainPolicy(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/domain-policy", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+// AddMailboxPolicy adds an entry to the whitelist or blacklist of a mailbox
+// and returns its ID, which is looked up as mailcow does not return it.
+func (c *Client) AddMailboxPolicy(ctx context.Context, policy MailboxPolicyRequest) (int64, error) {
+	_, err := c.post(ctx, "/api/v1/add/mailbox-policy", policy)
+	if err != nil {
+		return 0, err
+	}
+
+	policies, err := c.GetMailboxPolicies(ctx, policy.Username, policy.List)
+	if err != nil {
+		return 0, err
+	}
+
+	return findSpamPolicy(*policies, policy.From)
+}
+
+// GetMailboxPolicies returns the entries of the whitelist ("wl") or blacklist
+// ("bl") of a mailbox.
+func (c *Client) GetMailboxPolicies(ctx context.Context, username, list string) (*[]SpamPolicyResponse, error) {
+	var policies []SpamPolicyResponse
+	err := c.getList(ctx, "/api/v1/get/policy_"+list+"_mailbox/"+username, &policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policies, nil
+}
+
+func (c *Client) DeleteMailboxPolicy(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/mailbox-policy", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+func findSpamPolicy(policies []SpamPolicyResponse, from string) (int64, error) {
+	var id int64
+	for _, item := range policies {
+		if item.From == from && item.ID > id {
+			id = item.ID
+		}
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("policy for %s was added but cannot be found", from)
 	}
 
-	return nil
+	return id, nil
+}
+
+// AddAppPassword creates an app password and returns its ID, which is looked
+// up as the newest app password of the mailbox with the same name, as mailcow
+// does not return it.
+func (c *Client) AddAppPassword(ctx context.Context, appPassword AppPasswordRequest) (int64, error) {
+	_, err := c.post(ctx, "/api/v1/add/app-passwd", appPassword)
+	if err != nil {
+		return 0, err
+	}
+
+	appPasswords, err := c.GetAppPasswords(ctx, appPassword.Username)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	for _, item := range *appPasswords {
+		if item.Name == appPassword.Name && item.ID > id {
+			id = item.ID
+		}
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("app password %s was added but cannot be found", appPassword.Name)
+	}
+
+	return id, nil
+}
+
+// GetAppPassword returns an app password of a mailbox by ID.
+func (c *Client) GetAppPassword(ctx context.Context, username string, id int64) (*AppPasswordResponse, error) {
+	appPasswords, err := c.GetAppPasswords(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range *appPasswords {
+		if item.ID == id {
+			return &item, nil
+		}
+	}
+
+	return nil, &NotFoundError{Path: "/api/v1/get/app-passwd/all/" + username}
+}
+
+func (c *Client) GetAppPasswords(ctx context.Context, username string) (*[]AppPasswordResponse, error) {
+	var appPasswords []AppPasswordResponse
+	err := c.getList(ctx, "/api/v1/get/app-passwd/all/"+username, &appPasswords)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appPasswords, nil
+}
+
+func (c *Client) EditAppPassword(ctx context.Context, id int64, appPassword AppPasswordRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/app-passwd", editRequest{
+		Attr:  appPassword,
+		Items: []string{strconv.FormatInt(id, 10)},
+	})
+
+	return err
+}
+
+func (c *Client) DeleteAppPassword(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/app-passwd", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+// AddBCCMap creates a BCC map and returns its ID. The ID is taken from the
+// response like AddAlias does, or looked up as the newest BCC map of the same
+// local destination on mailcow versions that do not return it.
+func (c *Client) AddBCCMap(ctx context.Context, bccMap BCCMapRequest) (int64, error) {
+	res, err := c.post(ctx, "/api/v1/add/bcc", bccMap)
+	if err != nil {
+		return 0, err
+	}
+
+	if id, ok := responseID(res); ok {
+		return id, nil
+	}
+
+	bccMaps, err := c.GetAllBCCMaps(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	for _, item := range *bccMaps {
+		if item.LocalDest == bccMap.LocalDest && item.ID > id {
+			id = item.ID
+		}
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("BCC map for %s was added but cannot be found", bccMap.LocalDest)
+	}
+
+	return id, nil
+}
+
+func (c *Client) GetBCCMap(ctx context.Context, id int64) (*BCCMapResponse, error) {
+	var item BCCMapResponse
+	err := c.getObject(ctx, "/api/v1/get/bcc/"+strconv.FormatInt(id, 10), &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) GetAllBCCMaps(ctx context.Context) (*[]BCCMapResponse, error) {
+	var bccMaps []BCCMapResponse
+	err := c.getList(ctx, "/api/v1/get/bcc/all", &bccMaps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bccMaps, nil
+}
+
+func (c *Client) EditBCCMap(ctx context.Context, id int64, bccMap BCCMapRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/bcc", editRequest{
+		Attr:  bccMap,
+		Items: []string{strconv.FormatInt(id, 10)},
+	})
+
+	return err
+}
+
+func (c *Client) DeleteBCCMap(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/bcc", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+// AddRecipientMap creates a recipient map and returns its ID, taken from the
+// response or looked up by the old recipient like AddBCCMap.
+func (c *Client) AddRecipientMap(ctx context.Context, recipientMap RecipientMapRequest) (int64, error) {
+	res, err := c.post(ctx, "/api/v1/add/recipient_map", recipientMap)
+	if err != nil {
+		return 0, err
+	}
+
+	if id, ok := responseID(res); ok {
+		return id, nil
+	}
+
+	recipientMaps, err := c.GetAllRecipientMaps(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	for _, item := range *recipientMaps {
+		if item.Old == recipientMap.Old && item.ID > id {
+			id = item.ID
+		}
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("recipient map for %s was added but cannot be found", recipientMap.Old)
+	}
+
+	return id, nil
+}
+
+func (c *Client) GetRecipientMap(ctx context.Context, id int64) (*RecipientMapResponse, error) {
+	var item RecipientMapResponse
+	err := c.getObject(ctx, "/api/v1/get/recipient_map/"+strconv.FormatInt(id, 10), &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) GetAllRecipientMaps(ctx context.Context) (*[]RecipientMapResponse, error) {
+	var recipientMaps []RecipientMapResponse
+	err := c.getList(ctx, "/api/v1/get/recipient_map/all", &recipientMaps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recipientMaps, nil
+}
+
+func (c *Client) EditRecipientMap(ctx context.Context, id int64, recipientMap RecipientMapRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/recipient_map", editRequest{
+		Attr:  recipientMap,
+		Items: []string{strconv.FormatInt(id, 10)},
+	})
+
+	return err
+}
+
+func (c *Client) DeleteRecipientMap(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/recipient_map", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+// AddResource creates a resource and returns its name, which mailcow derives
+// from the description and returns as in ["resource_added", "room1@mailcow.tld"].
+func (c *Client) AddResource(ctx context.Context, resource ResourceRequest) (string, error) {
+	res, err := c.post(ctx, "/api/v1/add/resource", resource)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Message) < 2 || res.Message[1] == "" {
+		return "", fmt.Errorf("unexpected response from mailcow: %s", res.Message)
+	}
+
+	return res.Message[1], nil
+}
+
+func (c *Client) GetResource(ctx context.Context, name string) (*ResourceResponse, error) {
+	var item ResourceResponse
+	err := c.getObject(ctx, "/api/v1/get/resource/"+name, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) GetAllResources(ctx context.Context) (*[]ResourceResponse, error) {
+	var resources []ResourceResponse
+	err := c.getList(ctx, "/api/v1/get/resource/all", &resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resources, nil
+}
+
+func (c *Client) EditResource(ctx context.Context, name string, resource ResourceRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/resource", editRequest{
+		Attr:  resource,
+		Items: []string{name},
+	})
+
+	return err
+}
+
+func (c *Client) DeleteResource(ctx context.Context, name string) error {
+	_, err := c.post(ctx, "/api/v1/delete/resource", []string{name})
+
+	return err
+}
+
+// AddMailboxFilter creates a Sieve filter and returns its ID, which is looked
+// up as the newest filter of the mailbox with the same type and description,
+// as mailcow does not return it.
+func (c *Client) AddMailboxFilter(ctx context.Context, filter MailboxFilterRequest) (int64, error) {
+	_, err := c.post(ctx, "/api/v1/add/filter", filter)
+	if err != nil {
+		return 0, err
+	}
+
+	filters, err := c.GetMailboxFilters(ctx, filter.Username)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	for _, item := range *filters {
+		if item.FilterType == filter.FilterType && item.Description == filter.Description && item.ID > id {
+			id = item.ID
+		}
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("filter %s was added but cannot be found", filter.Description)
+	}
+
+	return id, nil
+}
+
+// GetMailboxFilter returns a Sieve filter of a mailbox by ID.
+func (c *Client) GetMailboxFilter(ctx context.Context, username string, id int64) (*MailboxFilterResponse, error) {
+	filters, err := c.GetMailboxFilters(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range *filters {
+		if item.ID == id {
+			return &item, nil
+		}
+	}
+
+	return nil, &NotFoundError{Path: "/api/v1/get/filters/" + username}
+}
+
+func (c *Client) GetMailboxFilters(ctx context.Context, username string) (*[]MailboxFilterResponse, error) {
+	var filters []MailboxFilterResponse
+	err := c.getList(ctx, "/api/v1/get/filters/"+username, &filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filters, nil
+}
+
+func (c *Client) EditMailboxFilter(ctx context.Context, id int64, filter MailboxFilterRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/filter", editRequest{
+		Attr:  filter,
+		Items: []string{strconv.FormatInt(id, 10)},
+	})
+
+	return err
+}
+
+func (c *Client) DeleteMailboxFilter(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/filter", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+// AddTLSPolicy creates a TLS policy map entry and returns its ID, which is
+// looked up by destination as mailcow does not return it.
+func (c *Client) AddTLSPolicy(ctx context.Context, policy TLSPolicyRequest) (int64, error) {
+	_, err := c.post(ctx, "/api/v1/add/tls-policy-map", policy)
+	if err != nil {
+		return 0, err
+	}
+
+	policies, err := c.GetAllTLSPolicies(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, item := range *policies {
+		if item.Dest == policy.Dest {
+			return item.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("TLS policy for %s was added but cannot be found", policy.Dest)
+}
+
+func (c *Client) GetTLSPolicy(ctx context.Context, id int64) (*TLSPolicyResponse, error) {
+	var item TLSPolicyResponse
+	err := c.getObject(ctx, "/api/v1/get/tls-policy-map/"+strconv.FormatInt(id, 10), &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) GetAllTLSPolicies(ctx context.Context) (*[]TLSPolicyResponse, error) {
+	var policies []TLSPolicyResponse
+	err := c.getList(ctx, "/api/v1/get/tls-policy-map/all", &policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policies, nil
+}
+
+func (c *Client) EditTLSPolicy(ctx context.Context, id int64, policy TLSPolicyRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/tls-policy-map", editRequest{
+		Attr:  policy,
+		Items: []string{strconv.FormatInt(id, 10)},
+	})
+
+	return err
+}
+
+func (c *Client) DeleteTLSPolicy(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/tls-policy-map", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+// GetDomainRatelimit returns the rate limit of a domain, or a NotFoundError
+// when it has none.
+func (c *Client) GetDomainRatelimit(ctx context.Context, domain string) (*RatelimitResponse, error) {
+	var item RatelimitResponse
+	err := c.getObject(ctx, "/api/v1/get/rl-domain/"+domain, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) EditDomainRatelimit(ctx context.Context, domain string, ratelimit RatelimitRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/rl-domain", editRequest{
+		Attr:  ratelimit,
+		Items: []string{domain},
+	})
+
+	return err
+}
+
+// DeleteDomainRatelimit clears the rate limit of a domain, which mailcow does
+// for an empty value.
+func (c *Client) DeleteDomainRatelimit(ctx context.Context, domain string) error {
+	return c.EditDomainRatelimit(ctx, domain, RatelimitRequest{Value: "", Frame: "s"})
+}
+
+// GetMailboxRatelimit returns the rate limit of a mailbox, or a NotFoundError
+// when it has none.
+func (c *Client) GetMailboxRatelimit(ctx context.Context, username string) (*RatelimitResponse, error) {
+	var item RatelimitResponse
+	err := c.getObject(ctx, "/api/v1/get/rl-mbox/"+username, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) EditMailboxRatelimit(ctx context.Context, username string, ratelimit RatelimitRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/rl-mbox", editRequest{
+		Attr:  ratelimit,
+		Items: []string{username},
+	})
+
+	return err
+}
+
+// DeleteMailboxRatelimit clears the rate limit of a mailbox, which mailcow
+// does for an empty value.
+func (c *Client) DeleteMailboxRatelimit(ctx context.Context, username string) error {
+	return c.EditMailboxRatelimit(ctx, username, RatelimitRequest{Value: "", Frame: "s"})
+}
+
+// AddForwardingHost adds a forwarding host. mailcow resolves a hostname to its
+// IPs and stores each of them with the hostname as source.
+func (c *Client) AddForwardingHost(ctx context.Context, host ForwardingHostRequest) error {
+	_, err := c.post(ctx, "/api/v1/add/fwdhost", host)
+
+	return err
+}
+
+// GetForwardingHost returns the IPs added for source, or a NotFoundError when
+// there are none.
+func (c *Client) GetForwardingHost(ctx context.Context, source string) (*[]ForwardingHostResponse, error) {
+	hosts, err := c.GetAllForwardingHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ForwardingHostResponse
+	for _, item := range *hosts {
+		if item.Source == source {
+			result = append(result, item)
+		}
+	}
+	if len(result) == 0 {
+		return nil, &NotFoundError{Path: "/api/v1/get/fwdhost/all"}
+	}
+
+	return &result, nil
+}
+
+func (c *Client) GetAllForwardingHosts(ctx context.Context) (*[]ForwardingHostResponse, error) {
+	var hosts []ForwardingHostResponse
+	err := c.getList(ctx, "/api/v1/get/fwdhost/all", &hosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hosts, nil
+}
+
+func (c *Client) EditForwardingHosts(ctx context.Context, ips []string, host ForwardingHostEditRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/fwdhost", editRequest{
+		Attr:  host,
+		Items: ips,
+	})
+
+	return err
+}
+
+func (c *Client) DeleteForwardingHosts(ctx context.Context, ips []string) error {
+	_, err := c.post(ctx, "/api/v1/delete/fwdhost", ips)
+
+	return err
+}
+
+// AddOAuth2Client creates an OAuth2 client and returns its ID, which is looked
+// up as the newest client with the same redirect URI, as mailcow does not
+// return it.
+func (c *Client) AddOAuth2Client(ctx context.Context, oauth2Client OAuth2ClientRequest) (int64, error) {
+	_, err := c.post(ctx, "/api/v1/add/oauth2-client", oauth2Client)
+	if err != nil {
+		return 0, err
+	}
+
+	clients, err := c.GetAllOAuth2Clients(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	for _, item := range *clients {
+		if item.RedirectURI == oauth2Client.RedirectURI && item.ID > id {
+			id = item.ID
+		}
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("OAuth2 client for %s was added but cannot be found", oauth2Client.RedirectURI)
+	}
+
+	return id, nil
+}
+
+func (c *Client) GetOAuth2Client(ctx context.Context, id int64) (*OAuth2ClientResponse, error) {
+	var item OAuth2ClientResponse
+	err := c.getObject(ctx, "/api/v1/get/oauth2-client/"+strconv.FormatInt(id, 10), &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) GetAllOAuth2Clients(ctx context.Context) (*[]OAuth2ClientResponse, error) {
+	var clients []OAuth2ClientResponse
+	err := c.getList(ctx, "/api/v1/get/oauth2-client/all", &clients)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clients, nil
+}
+
+func (c *Client) EditOAuth2Client(ctx context.Context, id int64, oauth2Client OAuth2ClientRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/oauth2-client", editRequest{
+		Attr:  oauth2Client,
+		Items: []string{strconv.FormatInt(id, 10)},
+	})
+
+	return err
+}
+
+func (c *Client) DeleteOAuth2Client(ctx context.Context, id int64) error {
+	_, err := c.post(ctx, "/api/v1/delete/oauth2-client", []string{strconv.FormatInt(id, 10)})
+
+	return err
+}
+
+func (c *Client) GetFail2ban(ctx context.Context) (*Fail2banResponse, error) {
+	var item Fail2banResponse
+	err := c.getObject(ctx, "/api/v1/get/fail2ban", &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// EditFail2ban replaces the netfilter configuration. mailcow has only one, so
+// the items of the request are ignored.
+func (c *Client) EditFail2ban(ctx context.Context, fail2ban Fail2banRequest) error {
+	_, err := c.post(ctx, "/api/v1/edit/fail2ban", editRequest{
+		Attr:  fail2ban,
+		Items: []string{"none"},
+	})
+
+	return err
 }
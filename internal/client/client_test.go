@@ -0,0 +1,151 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func newTestClient(t *testing.T, apiKey string) (*client.Client, *mailcowtest.Server) {
+	t.Helper()
+
+	server := mailcowtest.NewServer("test-key")
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(&server.URL, &apiKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.MaxRetries = 0
+
+	return c, server
+}
+
+func TestDomainLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestClient(t, "test-key")
+
+	err := c.AddDomain(ctx, client.DomainRequest{
+		Domain:      "mailcow.tld",
+		Description: `quote " and backslash \`,
+		Active:      "1",
+		Quota:       "1024",
+	})
+	if err != nil {
+		t.Fatalf("AddDomain: %s", err)
+	}
+
+	domain, err := c.GetDomain(ctx, "mailcow.tld")
+	if err != nil {
+		t.Fatalf("GetDomain: %s", err)
+	}
+	if domain.Description != `quote " and backslash \` {
+		t.Errorf("description = %q", domain.Description)
+	}
+	if domain.QuotaBytes != 1024*1024*1024 {
+		t.Errorf("quota = %d", domain.QuotaBytes)
+	}
+
+	err = c.AddDomain(ctx, client.DomainRequest{Domain: "mailcow.tld"})
+	if !client.IsAlreadyExists(err) {
+		t.Errorf("AddDomain twice: expected already exists, got %v", err)
+	}
+
+	err = c.EditDomain(ctx, "mailcow.tld", client.DomainRequest{Description: "edited", Active: "0"})
+	if err != nil {
+		t.Fatalf("EditDomain: %s", err)
+	}
+
+	domains, err := c.GetAllDomains(ctx)
+	if err != nil {
+		t.Fatalf("GetAllDomains: %s", err)
+	}
+	if len(*domains) != 1 || (*domains)[0].Description != "edited" || (*domains)[0].Active != 0 {
+		t.Errorf("domains = %+v", *domains)
+	}
+
+	if err := c.DeleteDomain(ctx, "mailcow.tld"); err != nil {
+		t.Fatalf("DeleteDomain: %s", err)
+	}
+
+	_, err = c.GetDomain(ctx, "mailcow.tld")
+	if !client.IsNotFound(err) {
+		t.Errorf("GetDomain after delete: expected not found, got %v", err)
+	}
+}
+
+func TestAddAliasReturnsID(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestClient(t, "test-key")
+
+	if err := c.AddDomain(ctx, client.DomainRequest{Domain: "mailcow.tld", Active: "1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := c.AddAlias(ctx, client.AliasRequest{Address: "info@mailcow.tld", GoTo: "a@example.com,b@example.com", Active: "1"})
+	if err != nil {
+		t.Fatalf("AddAlias: %s", err)
+	}
+
+	alias, err := c.GetAlias(ctx, id)
+	if err != nil {
+		t.Fatalf("GetAlias: %s", err)
+	}
+	if alias.Address != "info@mailcow.tld" || alias.GoTo != "a@example.com,b@example.com" {
+		t.Errorf("alias = %+v", alias)
+	}
+
+	_, err = c.AddAlias(ctx, client.AliasRequest{Address: "info@mailcow.tld", GoTo: "c@example.com", Active: "1"})
+	if !client.IsAlreadyExists(err) {
+		t.Errorf("AddAlias twice: expected already exists, got %v", err)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestClient(t, "wrong-key")
+
+	_, err := c.GetAllDomains(ctx)
+	if !client.IsPermissionDenied(err) {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+
+	apiErr, ok := err.(*client.APIError)
+	if !ok {
+		t.Fatalf("expected *client.APIError, got %T", err)
+	}
+	if apiErr.StatusCode != 401 || apiErr.MessageKey != "authentication failed" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+}
+
+func TestDeleteMissingMailbox(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestClient(t, "test-key")
+
+	err := c.DeleteMailbox(ctx, "nobody@mailcow.tld")
+
+	apiErr, ok := err.(*client.APIError)
+	if !ok {
+		t.Fatalf("expected *client.APIError, got %T: %v", err, err)
+	}
+	if apiErr.Type != "danger" || apiErr.MessageKey != "access_denied" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+}
+
+func TestGetDomainPoliciesEmpty(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestClient(t, "test-key")
+
+	// mailcow answers {} rather than [] for an empty list
+	policies, err := c.GetDomainPolicies(ctx, "mailcow.tld", "wl")
+	if err != nil {
+		t.Fatalf("GetDomainPolicies: %s", err)
+	}
+	if len(*policies) != 0 {
+		t.Errorf("policies = %+v", *policies)
+	}
+}
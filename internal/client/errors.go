@@ -0,0 +1,110 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned by every Client method when mailcow rejects a request,
+// either with a non-200 status code or with a danger/error response envelope.
+type APIError struct {
+	StatusCode int
+	Type       string
+	MessageKey string
+	Args       []string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.MessageKey == "" {
+		return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+	}
+
+	if len(e.Args) == 0 {
+		return fmt.Sprintf("status: %d, %s: %s", e.StatusCode, e.Type, e.MessageKey)
+	}
+
+	return fmt.Sprintf("status: %d, %s: %s (%s)", e.StatusCode, e.Type, e.MessageKey, strings.Join(e.Args, ", "))
+}
+
+func newAPIError(statusCode int, response postResponse, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		Type:       string(response.Type),
+		Body:       string(body),
+	}
+
+	if len(response.Message) > 0 {
+		e.MessageKey = response.Message[0]
+		e.Args = response.Message[1:]
+	}
+
+	return e
+}
+
+// parseAPIError decodes a mailcow response envelope, which is either a single
+// {type, log, msg} object or an array of them, into an APIError.
+func parseAPIError(statusCode int, body []byte) *APIError {
+	var responses []postResponse
+	if err := json.Unmarshal(body, &responses); err == nil && len(responses) > 0 {
+		return newAPIError(statusCode, responses[0], body)
+	}
+
+	var response postResponse
+	if err := json.Unmarshal(body, &response); err == nil {
+		return newAPIError(statusCode, response, body)
+	}
+
+	return &APIError{StatusCode: statusCode, Body: string(body)}
+}
+
+// NotFoundError is returned when mailcow answers a lookup for a single object
+// with an empty response, which is how it reports objects that do not exist.
+type NotFoundError struct {
+	Path string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("object not found: %s", e.Path)
+}
+
+// IsNotFound reports whether err is a NotFoundError or an APIError for an
+// object that does not exist.
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return true
+	}
+
+	var e *APIError
+	if !errors.As(err, &e) {
+		return false
+	}
+
+	return e.StatusCode == http.StatusNotFound || strings.HasSuffix(e.MessageKey, "_not_found")
+}
+
+// IsAlreadyExists reports whether err is an APIError for an object that already exists.
+func IsAlreadyExists(err error) bool {
+	var e *APIError
+	if !errors.As(err, &e) {
+		return false
+	}
+
+	return strings.HasSuffix(e.MessageKey, "_exists") || e.MessageKey == "is_alias_or_mailbox"
+}
+
+// IsPermissionDenied reports whether err is an APIError caused by a missing or
+// insufficiently privileged API key.
+func IsPermissionDenied(err error) bool {
+	var e *APIError
+	if !errors.As(err, &e) {
+		return false
+	}
+
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden ||
+		e.MessageKey == "access_denied" || e.MessageKey == "authentication failed"
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter caps the number of requests in flight and spaces requests out to a
+// maximum rate. It is shared by every copy of a Client, so all resources and
+// data sources of a provider draw from the same budget.
+type limiter struct {
+	slots    chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// newLimiter returns a limiter allowing maxConcurrent requests in flight and
+// requestsPerSecond requests per second. Zero disables either limit.
+func newLimiter(maxConcurrent int, requestsPerSecond float64) *limiter {
+	l := &limiter{}
+
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	if requestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+
+	return l
+}
+
+// acquire blocks until a request may be sent or ctx is done. Every successful
+// acquire must be followed by a release.
+func (l *limiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if l.interval == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if err := sleep(ctx, wait); err != nil {
+		l.release()
+		return err
+	}
+
+	return nil
+}
+
+func (l *limiter) release() {
+	if l == nil || l.slots == nil {
+		return
+	}
+
+	<-l.slots
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLimiterMaxConcurrent(t *testing.T) {
+	l := newLimiter(2, 0)
+
+	var (
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+		wg          sync.WaitGroup
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := l.acquire(context.Background()); err != nil {
+				t.Errorf("acquire: %s", err)
+				return
+			}
+			defer l.release()
+
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("max requests in flight = %d, want 2", maxInFlight)
+	}
+}
+
+func TestLimiterRequestsPerSecond(t *testing.T) {
+	l := newLimiter(0, 50)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.acquire(context.Background()); err != nil {
+			t.Fatalf("acquire: %s", err)
+		}
+		l.release()
+	}
+
+	// The first request goes out at once, the other four 20ms apart
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("5 requests at 50 per second took %s, want at least 80ms", elapsed)
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	l := newLimiter(0, 0)
+
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		if err := l.acquire(context.Background()); err != nil {
+			t.Fatalf("acquire: %s", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("100 requests without limits took %s", elapsed)
+	}
+}
+
+func TestLimiterCancelWaitingForSlot(t *testing.T) {
+	l := newLimiter(1, 0)
+
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- l.acquire(ctx)
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("acquire = %v, want %s", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("acquire still blocked after its context was cancelled")
+	}
+
+	// The cancelled waiter must not hold a slot, so the next request only
+	// waits for the first to be released
+	l.release()
+	testLimiterAcquireNow(t, l)
+}
+
+func TestLimiterCancelWaitingForRate(t *testing.T) {
+	l := newLimiter(1, 1)
+
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire: %s", err)
+	}
+	l.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire = %v, want %s", err, context.DeadlineExceeded)
+	}
+
+	if len(l.slots) != 0 {
+		t.Errorf("%d slots held after the waiter gave up, want 0", len(l.slots))
+	}
+}
+
+func testLimiterAcquireNow(t *testing.T, l *limiter) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := l.acquire(ctx); err != nil {
+		t.Fatalf("acquire after release: %s", err)
+	}
+	l.release()
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// logSubsystem is the tflog subsystem for mailcow API traffic. Its level can be
+// set separately with TF_LOG_PROVIDER_MAILCOW_API, e.g. to TRACE for bodies.
+const logSubsystem = "api"
+
+const redacted = "***"
+
+// withLogging returns ctx carrying the api logging subsystem.
+func withLogging(ctx context.Context, req *http.Request) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_MAILCOW_API"))
+	ctx = tflog.SubsystemWith(ctx, logSubsystem, "method", req.Method)
+	ctx = tflog.SubsystemWith(ctx, logSubsystem, "path", req.URL.Path)
+
+	return ctx
+}
+
+func logRequest(ctx context.Context, req *http.Request, attempt int, body []byte) {
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending mailcow API request", map[string]interface{}{
+		"attempt": attempt,
+	})
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "mailcow API request details", map[string]interface{}{
+		"attempt": attempt,
+		"headers": redactHeaders(req.Header),
+		"body":    redactBody(body),
+	})
+}
+
+func logResponse(ctx context.Context, statusCode int, latency time.Duration, body []byte, err error) {
+	fields := map[string]interface{}{
+		"status_code": statusCode,
+		"latency_ms":  latency.Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, logSubsystem, "mailcow API request failed", fields)
+		return
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received mailcow API response", fields)
+
+	fields["body"] = redactBody(body)
+	tflog.SubsystemTrace(ctx, logSubsystem, "mailcow API response details", fields)
+}
+
+func logRetry(ctx context.Context, attempt, maxRetries int, wait time.Duration, statusCode int, err error) {
+	fields := map[string]interface{}{
+		"attempt":     attempt,
+		"max_retries": maxRetries,
+		"wait_ms":     wait.Milliseconds(),
+		"status_code": statusCode,
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+
+	tflog.SubsystemWarn(ctx, logSubsystem, "Retrying mailcow API request", fields)
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := map[string]string{}
+	for name, values := range header {
+		if strings.EqualFold(name, "X-API-Key") || strings.EqualFold(name, "Authorization") {
+			headers[name] = redacted
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	return headers
+}
+
+// isSensitiveField reports whether a JSON field holds a secret, such as
+// password, password2, client_secret or a DKIM privkey.
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+
+	return strings.Contains(name, "pass") || strings.Contains(name, "secret") || strings.Contains(name, "privkey")
+}
+
+// redactBody masks sensitive fields anywhere in a JSON body. Bodies that are
+// not JSON are returned unchanged.
+func redactBody(body []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveField(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := []byte(`{"attr":{"name":"User","password":"secret1","password2":"secret1"},"items":["user@mailcow.tld"]}`)
+
+	got := redactBody(body)
+	want := `{"attr":{"name":"User","password":"***","password2":"***"},"items":["user@mailcow.tld"]}`
+	if got != want {
+		t.Errorf("redactBody() = %s, want %s", got, want)
+	}
+
+	if got := redactBody([]byte("not json")); got != "not json" {
+		t.Errorf("redactBody() = %s, want body unchanged", got)
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-API-Key", "key")
+	header.Set("Content-Type", "application/json")
+
+	got := redactHeaders(header)
+	if got["X-Api-Key"] != redacted {
+		t.Errorf("X-API-Key = %q, want it redacted", got["X-Api-Key"])
+	}
+	if got["Content-Type"] != "application/json" {
+		t.Errorf("Content-Type = %q", got["Content-Type"])
+	}
+}
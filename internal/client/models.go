@@ -1,5 +1,10 @@
 package client
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 type postResponseType string
 
 const (
@@ -10,7 +15,54 @@ const (
 
 type postResponse struct {
 	Type    postResponseType `json:"type"`
-	Message string           `json:"msg"`
+	Log     json.RawMessage  `json:"log"`
+	Message postMessage      `json:"msg"`
+}
+
+// postMessage holds the msg field of a mailcow response, which is either a
+// single string or an array such as ["mailbox_added", "user@mailcow.tld"].
+type postMessage []string
+
+func (m *postMessage) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*m = postMessage{single}
+		return nil
+	}
+
+	var multiple []interface{}
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+
+	*m = postMessage{}
+	for _, item := range multiple {
+		switch v := item.(type) {
+		case string:
+			*m = append(*m, v)
+		default:
+			encoded, _ := json.Marshal(v)
+			*m = append(*m, string(encoded))
+		}
+	}
+
+	return nil
+}
+
+func (m postMessage) String() string {
+	return strings.Join(m, ", ")
+}
+
+// editRequest is the envelope shared by all /api/v1/edit endpoints.
+type editRequest struct {
+	Attr  interface{} `json:"attr"`
+	Items []string    `json:"items"`
+}
+
+type AliasRequest struct {
+	Address string `json:"address"`
+	GoTo    string `json:"goto"`
+	Active  string `json:"active"`
 }
 
 type AliasResponse struct {
@@ -22,21 +74,353 @@ type AliasResponse struct {
 }
 
 type DomainResponse struct {
-	Name                    string `json:"domain_name"`
-	Description             string `json:"description"`
-	Active                  int64  `json:"active"`
-	QuotaBytes              int64  `json:"max_quota_for_domain"`
-	Mailboxes               int64  `json:"max_num_mboxes_for_domain"`
-	MailboxDefaultSizeBytes int64  `json:"def_new_mailbox_quota"`
-	MailboxMaxSizeBytes     int64  `json:"max_quota_for_mbox"`
-	Aliases                 int64  `json:"max_num_aliases_for_domain"`
+	Name                    string      `json:"domain_name"`
+	Description             string      `json:"description"`
+	Active                  int64       `json:"active"`
+	QuotaBytes              int64       `json:"max_quota_for_domain"`
+	Mailboxes               int64       `json:"max_num_mboxes_for_domain"`
+	MailboxDefaultSizeBytes int64       `json:"def_new_mailbox_quota"`
+	MailboxMaxSizeBytes     int64       `json:"max_quota_for_mbox"`
+	Aliases                 int64       `json:"max_num_aliases_for_domain"`
+	RelayHost               json.Number `json:"relayhost"`
+}
+
+type DomainRequest struct {
+	Domain      string `json:"domain,omitempty"`
+	Description string `json:"description"`
+	Active      string `json:"active"`
+	Aliases     string `json:"aliases"`
+	Mailboxes   string `json:"mailboxes"`
+	DefQuota    string `json:"defquota"`
+	MaxQuota    string `json:"maxquota"`
+	Quota       string `json:"quota"`
+	RelayHost   string `json:"relayhost,omitempty"`
 }
 
 type MailboxResponse struct {
-	Username string `json:"local_part"`
-	Domain   string `json:"domain"`
-	Email    string `json:"username"`
-	Active   int    `json:"active"`
-	Name     string `json:"name"`
-	Quota    int64  `json:"quota"`
+	Username   string                    `json:"local_part"`
+	Domain     string                    `json:"domain"`
+	Email      string                    `json:"username"`
+	Active     int                       `json:"active"`
+	Name       string                    `json:"name"`
+	Quota      int64                     `json:"quota"`
+	Attributes MailboxAttributesResponse `json:"attributes"`
+}
+
+type MailboxAttributesResponse struct {
+	ForcePasswordUpdate string `json:"force_pw_update"`
+}
+
+type MailboxRequest struct {
+	LocalPart           string `json:"local_part,omitempty"`
+	Domain              string `json:"domain,omitempty"`
+	Name                string `json:"name"`
+	Quota               string `json:"quota"`
+	Password            string `json:"password,omitempty"`
+	PasswordConfirm     string `json:"password2,omitempty"`
+	Active              string `json:"active"`
+	ForcePasswordUpdate string `json:"force_pw_update"`
+}
+
+type AliasDomainRequest struct {
+	AliasDomain  string `json:"alias_domain,omitempty"`
+	TargetDomain string `json:"target_domain"`
+	Active       string `json:"active"`
+}
+
+type AliasDomainResponse struct {
+	AliasDomain  string `json:"alias_domain"`
+	TargetDomain string `json:"target_domain"`
+	Active       int64  `json:"active"`
+}
+
+type DKIMRequest struct {
+	Domains  string `json:"domains"`
+	Selector string `json:"dkim_selector"`
+	KeySize  string `json:"key_size"`
+}
+
+type DKIMDuplicateRequest struct {
+	FromDomain string `json:"from_domain"`
+	ToDomain   string `json:"to_domain"`
+}
+
+type DKIMResponse struct {
+	PublicKey string      `json:"pubkey"`
+	Length    json.Number `json:"length"`
+	TXT       string      `json:"dkim_txt"`
+	Selector  string      `json:"dkim_selector"`
+}
+
+type DomainAdminRequest struct {
+	Username        string   `json:"username,omitempty"`
+	Domains         []string `json:"domains"`
+	Password        string   `json:"password,omitempty"`
+	PasswordConfirm string   `json:"password2,omitempty"`
+	Active          string   `json:"active"`
+}
+
+// DomainAdminACLRequest lists the ACLs a domain admin is granted, every ACL
+// not listed is revoked.
+type DomainAdminACLRequest struct {
+	ACL []string `json:"da_acl"`
+}
+
+type DomainAdminResponse struct {
+	Username string                 `json:"username"`
+	Active   int64                  `json:"active"`
+	Domains  []string               `json:"selected_domains"`
+	ACL      map[string]json.Number `json:"da_acl"`
+}
+
+// RelayhostRequest leaves out a nil Password, so mailcow keeps the current one.
+type RelayhostRequest struct {
+	Hostname string  `json:"hostname"`
+	Username string  `json:"username"`
+	Password *string `json:"password,omitempty"`
+	Active   string  `json:"active"`
+}
+
+type RelayhostResponse struct {
+	ID       int64  `json:"id"`
+	Hostname string `json:"hostname"`
+	Username string `json:"username"`
+	Active   int64  `json:"active"`
+}
+
+// TransportRequest leaves out a nil Password, so mailcow keeps the current one.
+type TransportRequest struct {
+	Destination string  `json:"destination"`
+	Nexthop     string  `json:"nexthop"`
+	Username    string  `json:"username"`
+	Password    *string `json:"password,omitempty"`
+	IsMXBased   string  `json:"is_mx_based"`
+	Active      string  `json:"active"`
+}
+
+type TransportResponse struct {
+	ID          int64  `json:"id"`
+	Destination string `json:"destination"`
+	Nexthop     string `json:"nexthop"`
+	Username    string `json:"username"`
+	IsMXBased   int64  `json:"is_mx_based"`
+	Active      int64  `json:"active"`
+}
+
+type SyncJobRequest struct {
+	Username     string `json:"username,omitempty"`
+	Host         string `json:"host1"`
+	Port         string `json:"port1"`
+	Encryption   string `json:"enc1"`
+	User         string `json:"user1"`
+	Password     string `json:"password1,omitempty"`
+	Interval     string `json:"mins_interval"`
+	DeleteSource string `json:"delete1"`
+	Exclude      string `json:"exclude"`
+	Subfolder    string `json:"subfolder2"`
+	CustomParams string `json:"custom_params"`
+	Active       string `json:"active"`
+}
+
+type SyncJobResponse struct {
+	ID           int64  `json:"id"`
+	Username     string `json:"user2"`
+	Host         string `json:"host1"`
+	Port         int64  `json:"port1"`
+	Encryption   string `json:"enc1"`
+	User         string `json:"user1"`
+	Interval     int64  `json:"mins_interval"`
+	DeleteSource int64  `json:"delete1"`
+	Exclude      string `json:"exclude"`
+	Subfolder    string `json:"subfolder2"`
+	CustomParams string `json:"custom_params"`
+	Active       int64  `json:"active"`
+	IsRunning    int64  `json:"is_running"`
+	LastRun      string `json:"last_run"`
+	Success      *int64 `json:"success"`
+	ExitStatus   string `json:"exit_status"`
+}
+
+type DomainPolicyRequest struct {
+	Domain string `json:"domain"`
+	List   string `json:"object_list"`
+	From   string `json:"object_from"`
+}
+
+type MailboxPolicyRequest struct {
+	Username string `json:"username"`
+	List     string `json:"object_list"`
+	From     string `json:"object_from"`
+}
+
+// SpamPolicyResponse is an entry of a domain or mailbox whitelist or blacklist.
+type SpamPolicyResponse struct {
+	ID     int64  `json:"prefid"`
+	Object string `json:"object"`
+	From   string `json:"value"`
+}
+
+type AppPasswordRequest struct {
+	Username        string   `json:"username,omitempty"`
+	Name            string   `json:"app_name"`
+	Password        string   `json:"app_passwd,omitempty"`
+	PasswordConfirm string   `json:"app_passwd2,omitempty"`
+	Active          string   `json:"active"`
+	Protocols       []string `json:"protocols"`
+}
+
+type AppPasswordResponse struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Mailbox    string `json:"mailbox"`
+	Active     int64  `json:"active"`
+	IMAPAccess int64  `json:"imap_access"`
+	SMTPAccess int64  `json:"smtp_access"`
+	DAVAccess  int64  `json:"dav_access"`
+	EASAccess  int64  `json:"eas_access"`
+	POP3Access int64  `json:"pop3_access"`
+}
+
+type BCCMapRequest struct {
+	LocalDest string `json:"local_dest"`
+	BCCDest   string `json:"bcc_dest"`
+	Type      string `json:"type"`
+	Active    string `json:"active"`
+}
+
+type BCCMapResponse struct {
+	ID        int64  `json:"id"`
+	LocalDest string `json:"local_dest"`
+	BCCDest   string `json:"bcc_dest"`
+	Type      string `json:"type"`
+	Active    int64  `json:"active"`
+}
+
+type RecipientMapRequest struct {
+	Old    string `json:"recipient_map_old"`
+	New    string `json:"recipient_map_new"`
+	Active string `json:"active"`
+}
+
+type RecipientMapResponse struct {
+	ID     int64  `json:"id"`
+	Old    string `json:"recipient_map_old"`
+	New    string `json:"recipient_map_new"`
+	Active int64  `json:"active"`
+}
+
+type ResourceRequest struct {
+	Description      string `json:"description"`
+	Domain           string `json:"domain,omitempty"`
+	Kind             string `json:"kind"`
+	MultipleBookings string `json:"multiple_bookings"`
+	Active           string `json:"active"`
+}
+
+type ResourceResponse struct {
+	Name             string `json:"name"`
+	LocalPart        string `json:"local_part"`
+	Domain           string `json:"domain"`
+	Description      string `json:"description"`
+	Kind             string `json:"kind"`
+	MultipleBookings int64  `json:"multiple_bookings"`
+	Active           int64  `json:"active"`
+}
+
+type MailboxFilterRequest struct {
+	Username    string `json:"username,omitempty"`
+	FilterType  string `json:"filter_type"`
+	Description string `json:"script_desc"`
+	ScriptData  string `json:"script_data"`
+	Active      string `json:"active"`
+}
+
+// MailboxFilterResponse is a Sieve filter of a mailbox. mailcow reports
+// whether it is active as a ScriptName of "active" or "inactive".
+type MailboxFilterResponse struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	Description string `json:"script_desc"`
+	ScriptName  string `json:"script_name"`
+	ScriptData  string `json:"script_data"`
+	FilterType  string `json:"filter_type"`
+}
+
+type TLSPolicyRequest struct {
+	Dest       string `json:"dest"`
+	Policy     string `json:"policy"`
+	Parameters string `json:"parameters"`
+	Active     string `json:"active"`
+}
+
+type TLSPolicyResponse struct {
+	ID         int64  `json:"id"`
+	Dest       string `json:"dest"`
+	Policy     string `json:"policy"`
+	Parameters string `json:"parameters"`
+	Active     int64  `json:"active"`
+}
+
+type RatelimitRequest struct {
+	Value string `json:"rl_value"`
+	Frame string `json:"rl_frame"`
+}
+
+type RatelimitResponse struct {
+	Value json.Number `json:"value"`
+	Frame string      `json:"frame"`
+}
+
+type ForwardingHostRequest struct {
+	Hostname   string `json:"hostname"`
+	FilterSpam string `json:"filter_spam"`
+}
+
+// ForwardingHostEditRequest edits the IPs of a forwarding host, where mailcow
+// takes the inverse of filter_spam as keep_spam "yes" or "no".
+type ForwardingHostEditRequest struct {
+	KeepSpam string `json:"keep_spam"`
+}
+
+// ForwardingHostResponse is one IP of a forwarding host. Source is the
+// hostname or address it was added as.
+type ForwardingHostResponse struct {
+	Host     string `json:"host"`
+	Source   string `json:"source"`
+	KeepSpam string `json:"keep_spam"`
+}
+
+type OAuth2ClientRequest struct {
+	RedirectURI string `json:"redirect_uri"`
+	RenewSecret string `json:"renew_secret,omitempty"`
+}
+
+type OAuth2ClientResponse struct {
+	ID           int64  `json:"id"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RedirectURI  string `json:"redirect_uri"`
+	Scope        string `json:"scope"`
+}
+
+// Fail2banRequest is the netfilter configuration. Whitelist and Blacklist are
+// newline separated networks.
+type Fail2banRequest struct {
+	BanTime     int64  `json:"ban_time"`
+	MaxAttempts int64  `json:"max_attempts"`
+	RetryWindow int64  `json:"retry_window"`
+	NetbanIPv4  int64  `json:"netban_ipv4"`
+	NetbanIPv6  int64  `json:"netban_ipv6"`
+	Whitelist   string `json:"whitelist"`
+	Blacklist   string `json:"blacklist"`
+}
+
+type Fail2banResponse struct {
+	BanTime     int64  `json:"ban_time"`
+	MaxAttempts int64  `json:"max_attempts"`
+	RetryWindow int64  `json:"retry_window"`
+	NetbanIPv4  int64  `json:"netban_ipv4"`
+	NetbanIPv6  int64  `json:"netban_ipv6"`
+	Whitelist   string `json:"whitelist"`
+	Blacklist   string `json:"blacklist"`
 }
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// shouldRetry reports whether a request may be sent again after it failed with
+// err or returned statusCode. Requests creating objects are only retried when
+// mailcow cannot have processed them, so a retry never creates a duplicate.
+func shouldRetry(req *http.Request, statusCode int, err error) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	creates := req.Method == http.MethodPost && strings.Contains(req.URL.Path, "/api/v1/add/")
+
+	if err != nil {
+		return !creates
+	}
+
+	switch {
+	case statusCode == http.StatusTooManyRequests, statusCode == http.StatusServiceUnavailable:
+		return true
+	case statusCode >= http.StatusInternalServerError:
+		return !creates
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before the given retry attempt, doubling
+// from min with up to 50% random jitter on top. A Retry-After header on the
+// previous response takes precedence. The result is always kept within min
+// and max.
+func backoff(min, max time.Duration, attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return clampDuration(time.Duration(seconds)*time.Second, min, max)
+		}
+	}
+
+	wait := float64(min) * math.Pow(2, float64(attempt))
+	jitter := rand.Float64() * wait / 2
+
+	return clampDuration(time.Duration(math.Min(wait+jitter, float64(max))), min, max)
+}
+
+func clampDuration(d, min, max time.Duration) time.Duration {
+	if d < min {
+		return min
+	}
+	if d > max {
+		return max
+	}
+
+	return d
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	min, max := 2*time.Second, 10*time.Second
+
+	for attempt := 0; attempt < 8; attempt++ {
+		for i := 0; i < 100; i++ {
+			got := backoff(min, max, attempt, nil)
+			if got < min || got > max {
+				t.Fatalf("backoff(attempt %d) = %s, want between %s and %s", attempt, got, min, max)
+			}
+		}
+	}
+
+	if got := backoff(min, max, 10, nil); got != max {
+		t.Errorf("backoff(attempt 10) = %s, want %s", got, max)
+	}
+}
+
+func TestBackoffRetryAfter(t *testing.T) {
+	min, max := 2*time.Second, 10*time.Second
+
+	tests := []struct {
+		retryAfter string
+		want       time.Duration
+	}{
+		{"5", 5 * time.Second},
+		{"0", min},
+		{"3600", max},
+		// Values that are not a number of seconds fall back to the
+		// exponential wait, which for the first attempt is min to 1.5*min.
+		{"-1", 0},
+		{"soon", 0},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		res := &http.Response{Header: http.Header{"Retry-After": []string{tt.retryAfter}}}
+		got := backoff(min, max, 0, res)
+
+		if tt.want == 0 {
+			if got < min || got > min*3/2 {
+				t.Errorf("backoff(Retry-After %q) = %s, want between %s and %s", tt.retryAfter, got, min, min*3/2)
+			}
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("backoff(Retry-After %q) = %s, want %s", tt.retryAfter, got, tt.want)
+		}
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	errConn := errors.New("connection reset by peer")
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		statusCode int
+		err        error
+		want       bool
+	}{
+		{"get connection error", http.MethodGet, "/api/v1/get/domain/all", 0, errConn, true},
+		{"get too many requests", http.MethodGet, "/api/v1/get/domain/all", http.StatusTooManyRequests, nil, true},
+		{"get service unavailable", http.MethodGet, "/api/v1/get/domain/all", http.StatusServiceUnavailable, nil, true},
+		{"get internal server error", http.MethodGet, "/api/v1/get/domain/all", http.StatusInternalServerError, nil, true},
+		{"get bad gateway", http.MethodGet, "/api/v1/get/domain/all", http.StatusBadGateway, nil, true},
+		{"get ok", http.MethodGet, "/api/v1/get/domain/all", http.StatusOK, nil, false},
+		{"get not found", http.MethodGet, "/api/v1/get/domain/all", http.StatusNotFound, nil, false},
+		{"get unauthorized", http.MethodGet, "/api/v1/get/domain/all", http.StatusUnauthorized, nil, false},
+		{"edit connection error", http.MethodPost, "/api/v1/edit/domain", 0, errConn, true},
+		{"edit internal server error", http.MethodPost, "/api/v1/edit/domain", http.StatusInternalServerError, nil, true},
+		{"delete gateway timeout", http.MethodPost, "/api/v1/delete/domain", http.StatusGatewayTimeout, nil, true},
+		{"add connection error", http.MethodPost, "/api/v1/add/domain", 0, errConn, false},
+		{"add internal server error", http.MethodPost, "/api/v1/add/domain", http.StatusInternalServerError, nil, false},
+		{"add bad gateway", http.MethodPost, "/api/v1/add/domain", http.StatusBadGateway, nil, false},
+		{"add gateway timeout", http.MethodPost, "/api/v1/add/domain", http.StatusGatewayTimeout, nil, false},
+		{"add too many requests", http.MethodPost, "/api/v1/add/domain", http.StatusTooManyRequests, nil, true},
+		{"add service unavailable", http.MethodPost, "/api/v1/add/domain", http.StatusServiceUnavailable, nil, true},
+		{"add bad request", http.MethodPost, "/api/v1/add/domain", http.StatusBadRequest, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "https://mail.example.com"+tt.path, bytes.NewReader([]byte("{}")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := shouldRetry(req, tt.statusCode, tt.err); got != tt.want {
+				t.Errorf("shouldRetry() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldRetryUnreplayableBody(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://mail.example.com/api/v1/edit/domain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader([]byte("{}")))
+
+	if shouldRetry(req, http.StatusServiceUnavailable, nil) {
+		t.Error("shouldRetry() = true for a body that cannot be sent again, want false")
+	}
+}
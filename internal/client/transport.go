@@ -0,0 +1,96 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportConfig describes how the client connects to the mailcow server.
+type TransportConfig struct {
+	// CACertPEM or CACertFile adds a certificate authority to the system pool.
+	CACertPEM  string
+	CACertFile string
+
+	// ClientCertPEM and ClientKeyPEM enable mutual TLS when both are set.
+	ClientCertPEM string
+	ClientKeyPEM  string
+
+	InsecureSkipVerify bool
+
+	// ProxyURL overrides the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment.
+	ProxyURL string
+
+	// Timeout limits a single request attempt, zero means no timeout.
+	Timeout time.Duration
+}
+
+// NewHTTPClient builds an http.Client from config.
+func NewHTTPClient(config TransportConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.CACertPEM != "" && config.CACertFile != "" {
+		return nil, errors.New("CA certificate PEM and CA certificate file cannot both be set")
+	}
+
+	if config.CACertPEM != "" || config.CACertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if config.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(config.CACertPEM)) {
+			return nil, errors.New("no valid certificate found in CA certificate PEM")
+		}
+
+		if config.CACertFile != "" {
+			data, err := ioutil.ReadFile(config.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading CA certificate file: %w", err)
+			}
+
+			if !pool.AppendCertsFromPEM(data) {
+				return nil, fmt.Errorf("no valid certificate found in %s", config.CACertFile)
+			}
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCertPEM != "" || config.ClientKeyPEM != "" {
+		if config.ClientCertPEM == "" || config.ClientKeyPEM == "" {
+			return nil, errors.New("client certificate and client key must be set together")
+		}
+
+		certificate, err := tls.X509KeyPair([]byte(config.ClientCertPEM), []byte(config.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if config.ProxyURL != "" {
+		proxy, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy URL: %w", err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   config.Timeout,
+	}, nil
+}
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificate returns a self-signed PEM encoded certificate and its key.
+func testCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "mailcow test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	certPEM, keyPEM := testCertificate(t)
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := ioutil.WriteFile(caFile, []byte(certPEM), 0600); err != nil {
+		t.Fatal(err)
+	}
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	if err := ioutil.WriteFile(invalidFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		config TransportConfig
+		// err is a substring of the expected error, empty for a valid config
+		err   string
+		check func(t *testing.T, transport *http.Transport, c *http.Client)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, transport *http.Transport, c *http.Client) {
+				if transport.TLSClientConfig.RootCAs != nil {
+					t.Error("RootCAs set without a CA certificate, want the system pool")
+				}
+				if len(transport.TLSClientConfig.Certificates) != 0 {
+					t.Error("client certificate set without client_cert")
+				}
+				if transport.TLSClientConfig.InsecureSkipVerify {
+					t.Error("InsecureSkipVerify = true, want false")
+				}
+				if c.Timeout != 0 {
+					t.Errorf("Timeout = %s, want none", c.Timeout)
+				}
+			},
+		},
+		{
+			name:   "CA certificate PEM",
+			config: TransportConfig{CACertPEM: certPEM},
+			check: func(t *testing.T, transport *http.Transport, c *http.Client) {
+				if transport.TLSClientConfig.RootCAs == nil {
+					t.Error("RootCAs = nil, want a pool with the CA certificate")
+				}
+			},
+		},
+		{
+			name:   "CA certificate file",
+			config: TransportConfig{CACertFile: caFile},
+			check: func(t *testing.T, transport *http.Transport, c *http.Client) {
+				if transport.TLSClientConfig.RootCAs == nil {
+					t.Error("RootCAs = nil, want a pool with the CA certificate")
+				}
+			},
+		},
+		{
+			name:   "CA certificate PEM and file",
+			config: TransportConfig{CACertPEM: certPEM, CACertFile: caFile},
+			err:    "cannot both be set",
+		},
+		{
+			name:   "invalid CA certificate PEM",
+			config: TransportConfig{CACertPEM: "not a certificate"},
+			err:    "no valid certificate found in CA certificate PEM",
+		},
+		{
+			name:   "invalid CA certificate file",
+			config: TransportConfig{CACertFile: invalidFile},
+			err:    "no valid certificate found in " + invalidFile,
+		},
+		{
+			name:   "missing CA certificate file",
+			config: TransportConfig{CACertFile: filepath.Join(dir, "missing.pem")},
+			err:    "reading CA certificate file",
+		},
+		{
+			name:   "client certificate and key",
+			config: TransportConfig{ClientCertPEM: certPEM, ClientKeyPEM: keyPEM},
+			check: func(t *testing.T, transport *http.Transport, c *http.Client) {
+				if len(transport.TLSClientConfig.Certificates) != 1 {
+					t.Errorf("%d client certificates, want 1", len(transport.TLSClientConfig.Certificates))
+				}
+			},
+		},
+		{
+			name:   "client certificate without key",
+			config: TransportConfig{ClientCertPEM: certPEM},
+			err:    "client certificate and client key must be set together",
+		},
+		{
+			name:   "client key without certificate",
+			config: TransportConfig{ClientKeyPEM: keyPEM},
+			err:    "client certificate and client key must be set together",
+		},
+		{
+			name:   "invalid client certificate",
+			config: TransportConfig{ClientCertPEM: "not a certificate", ClientKeyPEM: keyPEM},
+			err:    "loading client certificate",
+		},
+		{
+			name:   "invalid client key",
+			config: TransportConfig{ClientCertPEM: certPEM, ClientKeyPEM: "not a key"},
+			err:    "loading client certificate",
+		},
+		{
+			name:   "insecure skip verify",
+			config: TransportConfig{InsecureSkipVerify: true},
+			check: func(t *testing.T, transport *http.Transport, c *http.Client) {
+				if !transport.TLSClientConfig.InsecureSkipVerify {
+					t.Error("InsecureSkipVerify = false, want true")
+				}
+			},
+		},
+		{
+			name:   "proxy URL",
+			config: TransportConfig{ProxyURL: "http://proxy.example.com:3128"},
+			check: func(t *testing.T, transport *http.Transport, c *http.Client) {
+				req, _ := http.NewRequest(http.MethodGet, "https://mail.example.com/api/v1/get/domain/all", nil)
+				proxy, err := transport.Proxy(req)
+				if err != nil {
+					t.Fatalf("Proxy: %s", err)
+				}
+				if proxy == nil || proxy.String() != "http://proxy.example.com:3128" {
+					t.Errorf("Proxy = %v, want http://proxy.example.com:3128", proxy)
+				}
+			},
+		},
+		{
+			name:   "invalid proxy URL",
+			config: TransportConfig{ProxyURL: "://proxy.example.com"},
+			err:    "parsing proxy URL",
+		},
+		{
+			name:   "timeout",
+			config: TransportConfig{Timeout: 10 * time.Second},
+			check: func(t *testing.T, transport *http.Transport, c *http.Client) {
+				if c.Timeout != 10*time.Second {
+					t.Errorf("Timeout = %s, want 10s", c.Timeout)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := NewHTTPClient(test.config)
+			switch {
+			case test.err == "" && err != nil:
+				t.Fatalf("NewHTTPClient() = %s, want no error", err)
+			case test.err != "" && err == nil:
+				t.Fatalf("NewHTTPClient() = nil, want an error containing %q", test.err)
+			case test.err != "":
+				if !strings.Contains(err.Error(), test.err) {
+					t.Errorf("NewHTTPClient() = %s, want an error containing %q", err, test.err)
+				}
+				return
+			}
+
+			test.check(t, c.Transport.(*http.Transport), c)
+		})
+	}
+}
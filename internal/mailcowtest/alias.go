@@ -0,0 +1,115 @@
+package mailcowtest
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Alias struct {
+	ID      int64  `json:"id"`
+	Domain  string `json:"domain"`
+	GoTo    string `json:"goto"`
+	Address string `json:"address"`
+	Active  int64  `json:"active"`
+}
+
+// Alias returns a copy of the stored alias, or nil when it does not exist.
+func (s *Server) Alias(id int64) *Alias {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, ok := s.aliases[id]; ok {
+		copied := *a
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveAlias deletes an alias behind the provider's back.
+func (s *Server) RemoveAlias(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.aliases, id)
+}
+
+func (s *Server) aliasHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var ids []int64
+				for id := range s.aliases {
+					ids = append(ids, id)
+				}
+				if len(ids) == 0 {
+					// mailcow answers an empty list with {}
+					return nil
+				}
+
+				aliases := []Alias{}
+				for _, id := range sortedIDs(ids) {
+					aliases = append(aliases, *s.aliases[id])
+				}
+				return aliases
+			}
+
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if a, ok := s.aliases[id]; ok {
+				return a
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			address := str(attr["address"])
+			domain := address[strings.LastIndex(address, "@")+1:]
+			if _, ok := s.domains[domain]; !ok {
+				return *danger("domain_not_found", domain)
+			}
+			if s.addressTaken(address) {
+				return *danger("is_alias_or_mailbox", address)
+			}
+
+			id := s.newID("alias")
+			a := &Alias{ID: id, Domain: domain, Address: address, Active: 1}
+			applyAlias(a, attr)
+			s.aliases[id] = a
+
+			return success("alias_added", address, id)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			a, ok := s.aliases[id]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			applyAlias(a, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if _, ok := s.aliases[id]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.aliases, id)
+
+			return nil
+		},
+	}
+}
+
+func applyAlias(a *Alias, attr map[string]interface{}) {
+	if v, ok := attr["address"]; ok {
+		a.Address = str(v)
+	}
+	if v, ok := attr["goto"]; ok {
+		a.GoTo = str(v)
+	}
+	if v, ok := attr["active"]; ok {
+		a.Active = num(v)
+	}
+}
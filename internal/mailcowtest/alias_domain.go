@@ -0,0 +1,112 @@
+package mailcowtest
+
+type AliasDomain struct {
+	AliasDomain  string `json:"alias_domain"`
+	TargetDomain string `json:"target_domain"`
+	Active       int64  `json:"active"`
+}
+
+// AliasDomain returns a copy of the stored alias domain, or nil when it does
+// not exist.
+func (s *Server) AliasDomain(name string) *AliasDomain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, ok := s.aliasDomains[name]; ok {
+		copied := *a
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveAliasDomain deletes an alias domain behind the provider's back.
+func (s *Server) RemoveAliasDomain(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.aliasDomains, name)
+}
+
+func (s *Server) aliasDomainHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var names []string
+				for name := range s.aliasDomains {
+					names = append(names, name)
+				}
+				if len(names) == 0 {
+					// mailcow answers an empty list with {}
+					return nil
+				}
+
+				aliasDomains := []AliasDomain{}
+				for _, name := range sortedStrings(names) {
+					aliasDomains = append(aliasDomains, *s.aliasDomains[name])
+				}
+				return aliasDomains
+			}
+
+			if a, ok := s.aliasDomains[item]; ok {
+				return a
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			name, target := str(attr["alias_domain"]), str(attr["target_domain"])
+			if name == "" {
+				return *danger("alias_domain_invalid", name)
+			}
+			if _, ok := s.domains[target]; !ok {
+				return *danger("target_domain_invalid", target)
+			}
+			if _, ok := s.domains[name]; ok {
+				return *danger("alias_domain_exists", name)
+			}
+			if _, ok := s.aliasDomains[name]; ok {
+				return *danger("alias_domain_exists", name)
+			}
+
+			a := &AliasDomain{AliasDomain: name, Active: 1}
+			applyAliasDomain(a, attr)
+			s.aliasDomains[name] = a
+
+			return success("aliasd_added", name)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			a, ok := s.aliasDomains[item]
+			if !ok {
+				return danger("access_denied")
+			}
+			if v, ok := attr["target_domain"]; ok {
+				if _, ok := s.domains[str(v)]; !ok {
+					return danger("target_domain_invalid", str(v))
+				}
+			}
+
+			applyAliasDomain(a, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			if _, ok := s.aliasDomains[item]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.aliasDomains, item)
+
+			return nil
+		},
+	}
+}
+
+func applyAliasDomain(a *AliasDomain, attr map[string]interface{}) {
+	if v, ok := attr["target_domain"]; ok {
+		a.TargetDomain = str(v)
+	}
+	if v, ok := attr["active"]; ok {
+		a.Active = num(v)
+	}
+}
@@ -0,0 +1,138 @@
+package mailcowtest
+
+import (
+	"strconv"
+	"strings"
+)
+
+type AppPassword struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Mailbox    string `json:"mailbox"`
+	Domain     string `json:"domain"`
+	Active     int64  `json:"active"`
+	IMAPAccess int64  `json:"imap_access"`
+	SMTPAccess int64  `json:"smtp_access"`
+	DAVAccess  int64  `json:"dav_access"`
+	EASAccess  int64  `json:"eas_access"`
+	POP3Access int64  `json:"pop3_access"`
+	Password   string `json:"-"`
+}
+
+// AppPassword returns a copy of the stored app password, or nil when it does
+// not exist.
+func (s *Server) AppPassword(id int64) *AppPassword {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, ok := s.appPasswords[id]; ok {
+		copied := *a
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveAppPassword deletes an app password behind the provider's back.
+func (s *Server) RemoveAppPassword(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.appPasswords, id)
+}
+
+func (s *Server) appPasswordHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			// all/{mailbox} lists the app passwords of a mailbox
+			if !strings.HasPrefix(item, "all/") {
+				return nil
+			}
+			mailbox := strings.TrimPrefix(item, "all/")
+
+			var ids []int64
+			for id, a := range s.appPasswords {
+				if a.Mailbox == mailbox {
+					ids = append(ids, id)
+				}
+			}
+			if len(ids) == 0 {
+				return nil
+			}
+
+			appPasswords := []AppPassword{}
+			for _, id := range sortedIDs(ids) {
+				appPasswords = append(appPasswords, *s.appPasswords[id])
+			}
+			return appPasswords
+		},
+		add: func(attr map[string]interface{}) response {
+			username := str(attr["username"])
+			m, ok := s.mailboxes[username]
+			if !ok {
+				return *danger("access_denied")
+			}
+			if str(attr["app_name"]) == "" {
+				return *danger("app_name_empty")
+			}
+			if str(attr["app_passwd"]) == "" || str(attr["app_passwd"]) != str(attr["app_passwd2"]) {
+				return *danger("password_mismatch")
+			}
+
+			// mailcow does not return the ID of the new app password
+			a := &AppPassword{ID: s.newID("app_passwd"), Mailbox: username, Domain: m.Domain, Active: 1}
+			applyAppPassword(a, attr)
+			s.appPasswords[a.ID] = a
+
+			return success("app_passwd_added")
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			a, ok := s.appPasswords[id]
+			if !ok {
+				return danger("access_denied")
+			}
+			if _, ok := attr["app_passwd"]; ok && str(attr["app_passwd"]) != str(attr["app_passwd2"]) {
+				return danger("password_mismatch")
+			}
+
+			applyAppPassword(a, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if _, ok := s.appPasswords[id]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.appPasswords, id)
+
+			return nil
+		},
+	}
+}
+
+func applyAppPassword(a *AppPassword, attr map[string]interface{}) {
+	if v, ok := attr["app_name"]; ok {
+		a.Name = str(v)
+	}
+	if v, ok := attr["app_passwd"]; ok {
+		a.Password = str(v)
+	}
+	if v, ok := attr["active"]; ok {
+		a.Active = num(v)
+	}
+	if v, ok := attr["protocols"]; ok {
+		granted := map[string]int64{}
+		for _, protocol := range stringList(v) {
+			granted[protocol] = 1
+		}
+
+		a.IMAPAccess = granted["imap_access"]
+		a.SMTPAccess = granted["smtp_access"]
+		a.DAVAccess = granted["dav_access"]
+		a.EASAccess = granted["eas_access"]
+		a.POP3Access = granted["pop3_access"]
+	}
+}
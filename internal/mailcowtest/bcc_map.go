@@ -0,0 +1,114 @@
+package mailcowtest
+
+import "strconv"
+
+type BCCMap struct {
+	ID        int64  `json:"id"`
+	LocalDest string `json:"local_dest"`
+	BCCDest   string `json:"bcc_dest"`
+	Type      string `json:"type"`
+	Active    int64  `json:"active"`
+}
+
+// BCCMap returns a copy of the stored BCC map, or nil when it does not exist.
+func (s *Server) BCCMap(id int64) *BCCMap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok := s.bccMaps[id]; ok {
+		copied := *b
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveBCCMap deletes a BCC map behind the provider's back.
+func (s *Server) RemoveBCCMap(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.bccMaps, id)
+}
+
+func (s *Server) bccMapHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var ids []int64
+				for id := range s.bccMaps {
+					ids = append(ids, id)
+				}
+
+				bccMaps := []BCCMap{}
+				for _, id := range sortedIDs(ids) {
+					bccMaps = append(bccMaps, *s.bccMaps[id])
+				}
+				return bccMaps
+			}
+
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if b, ok := s.bccMaps[id]; ok {
+				return b
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			localDest, bccDest, kind := str(attr["local_dest"]), str(attr["bcc_dest"]), str(attr["type"])
+			if localDest == "" || bccDest == "" {
+				return *danger("bcc_must_be_email", bccDest)
+			}
+			if kind != "sender" && kind != "recipient" {
+				return *danger("invalid_bcc_map_type")
+			}
+			for _, b := range s.bccMaps {
+				if b.LocalDest == localDest && b.Type == kind {
+					return *danger("bcc_exists", localDest, kind)
+				}
+			}
+
+			b := &BCCMap{ID: s.newID("bcc_maps"), Active: 1}
+			applyBCCMap(b, attr)
+			s.bccMaps[b.ID] = b
+
+			return success("bcc_saved", localDest, strconv.FormatInt(b.ID, 10))
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			b, ok := s.bccMaps[id]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			applyBCCMap(b, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if _, ok := s.bccMaps[id]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.bccMaps, id)
+
+			return nil
+		},
+	}
+}
+
+func applyBCCMap(b *BCCMap, attr map[string]interface{}) {
+	if v, ok := attr["local_dest"]; ok {
+		b.LocalDest = str(v)
+	}
+	if v, ok := attr["bcc_dest"]; ok {
+		b.BCCDest = str(v)
+	}
+	if v, ok := attr["type"]; ok {
+		b.Type = str(v)
+	}
+	if v, ok := attr["active"]; ok {
+		b.Active = num(v)
+	}
+}
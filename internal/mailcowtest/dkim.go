@@ -0,0 +1,138 @@
+package mailcowtest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+)
+
+type DKIMKey struct {
+	PublicKey string `json:"pubkey"`
+	Length    int64  `json:"length"`
+	TXT       string `json:"dkim_txt"`
+	Selector  string `json:"dkim_selector"`
+	Private   string `json:"privkey"`
+}
+
+// DKIMKey returns a copy of the DKIM key of domain, or nil when it has none.
+func (s *Server) DKIMKey(domain string) *DKIMKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if k, ok := s.dkimKeys[domain]; ok {
+		copied := *k
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveDKIMKey deletes a DKIM key behind the provider's back.
+func (s *Server) RemoveDKIMKey(domain string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.dkimKeys, domain)
+}
+
+// AddDKIMKey generates a DKIM key for domain behind the provider's back.
+func (s *Server) AddDKIMKey(domain, selector string, length int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dkimKeys[domain] = newDKIMKey(selector, length)
+}
+
+func newDKIMKey(selector string, length int64) *DKIMKey {
+	public := make([]byte, length/16)
+	_, _ = rand.Read(public)
+	key := base64.StdEncoding.EncodeToString(public)
+
+	return &DKIMKey{
+		PublicKey: key,
+		Length:    length,
+		TXT:       "v=DKIM1;k=rsa;t=s;s=email;p=" + key,
+		Selector:  selector,
+		Private:   "private-" + key,
+	}
+}
+
+func (s *Server) dkimHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if k, ok := s.dkimKeys[item]; ok {
+				// mailcow never returns the private key through the API
+				copied := *k
+				copied.Private = ""
+				return copied
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			selector, length := str(attr["dkim_selector"]), num(attr["key_size"])
+			switch length {
+			case 1024, 2048, 3072, 4096:
+			default:
+				return *danger("dkim_key_length_invalid")
+			}
+			if selector == "" {
+				return *danger("dkim_selector_invalid")
+			}
+
+			domains := strings.FieldsFunc(str(attr["domains"]), func(r rune) bool { return r == ',' || r == ' ' })
+			for _, domain := range domains {
+				if !s.hasDomain(domain) {
+					return *danger("dkim_domain_or_sel_invalid", domain)
+				}
+				if _, ok := s.dkimKeys[domain]; ok {
+					return *danger("dkim_domain_or_sel_exists", domain)
+				}
+			}
+
+			for _, domain := range domains {
+				s.dkimKeys[domain] = newDKIMKey(selector, length)
+			}
+
+			return success("dkim_added", strings.Join(domains, ", "))
+		},
+		delete: func(item string) *response {
+			delete(s.dkimKeys, item)
+
+			return nil
+		},
+	}
+}
+
+func (s *Server) dkimDuplicateHandler() handler {
+	return handler{
+		add: func(attr map[string]interface{}) response {
+			from, to := str(attr["from_domain"]), str(attr["to_domain"])
+
+			source, ok := s.dkimKeys[from]
+			if !ok {
+				return *danger("dkim_domain_or_sel_invalid", from)
+			}
+			if !s.hasDomain(to) {
+				return *danger("dkim_domain_or_sel_invalid", to)
+			}
+
+			copied := *source
+			s.dkimKeys[to] = &copied
+
+			return success("dkim_duplicated", from, to)
+		},
+	}
+}
+
+// hasDomain reports whether name is a domain or an alias domain, both of which
+// can sign with DKIM.
+func (s *Server) hasDomain(name string) bool {
+	if _, ok := s.domains[name]; ok {
+		return true
+	}
+
+	_, ok := s.aliasDomains[name]
+
+	return ok
+}
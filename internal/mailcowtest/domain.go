@@ -0,0 +1,141 @@
+package mailcowtest
+
+type Domain struct {
+	Name                    string `json:"domain_name"`
+	Description             string `json:"description"`
+	Active                  int64  `json:"active"`
+	QuotaBytes              int64  `json:"max_quota_for_domain"`
+	Mailboxes               int64  `json:"max_num_mboxes_for_domain"`
+	MailboxDefaultSizeBytes int64  `json:"def_new_mailbox_quota"`
+	MailboxMaxSizeBytes     int64  `json:"max_quota_for_mbox"`
+	Aliases                 int64  `json:"max_num_aliases_for_domain"`
+	RelayHost               string `json:"relayhost"`
+}
+
+// Domain returns a copy of the stored domain, or nil when it does not exist.
+func (s *Server) Domain(name string) *Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d, ok := s.domains[name]; ok {
+		copied := *d
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveDomain deletes a domain behind the provider's back, as if it had been
+// removed in the mailcow UI.
+func (s *Server) RemoveDomain(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.domains, name)
+}
+
+func (s *Server) domainHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var names []string
+				for name := range s.domains {
+					names = append(names, name)
+				}
+				if len(names) == 0 {
+					// mailcow answers an empty list with {}
+					return nil
+				}
+
+				domains := []Domain{}
+				for _, name := range sortedStrings(names) {
+					domains = append(domains, *s.domains[name])
+				}
+				return domains
+			}
+
+			if d, ok := s.domains[item]; ok {
+				return d
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			name := str(attr["domain"])
+			if name == "" {
+				return *danger("domain_invalid")
+			}
+			if _, ok := s.domains[name]; ok {
+				return *danger("domain_exists", name)
+			}
+
+			d := &Domain{Name: name, Active: 1, RelayHost: "0"}
+			applyDomain(d, attr)
+			s.domains[name] = d
+
+			return success("domain_added", name)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			d, ok := s.domains[item]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			applyDomain(d, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			if _, ok := s.domains[item]; !ok {
+				return danger("domain_not_found", item)
+			}
+			for _, m := range s.mailboxes {
+				if m.Domain == item {
+					return danger("domain_not_empty", item)
+				}
+			}
+
+			delete(s.domains, item)
+			delete(s.dkimKeys, item)
+			delete(s.ratelimits, "domain/"+item)
+			for _, admin := range s.domainAdmins {
+				var domains []string
+				for _, domain := range admin.Domains {
+					if domain != item {
+						domains = append(domains, domain)
+					}
+				}
+				admin.Domains = domains
+			}
+
+			return nil
+		},
+	}
+}
+
+func applyDomain(d *Domain, attr map[string]interface{}) {
+	if v, ok := attr["description"]; ok {
+		d.Description = str(v)
+	}
+	if v, ok := attr["active"]; ok {
+		d.Active = num(v)
+	}
+	if v, ok := attr["quota"]; ok {
+		d.QuotaBytes = num(v) * 1024 * 1024
+	}
+	if v, ok := attr["mailboxes"]; ok {
+		d.Mailboxes = num(v)
+	}
+	if v, ok := attr["defquota"]; ok {
+		d.MailboxDefaultSizeBytes = num(v) * 1024 * 1024
+	}
+	if v, ok := attr["maxquota"]; ok {
+		d.MailboxMaxSizeBytes = num(v) * 1024 * 1024
+	}
+	if v, ok := attr["aliases"]; ok {
+		d.Aliases = num(v)
+	}
+	if v, ok := attr["relayhost"]; ok {
+		d.RelayHost = str(v)
+	}
+}
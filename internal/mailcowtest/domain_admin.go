@@ -0,0 +1,177 @@
+package mailcowtest
+
+// defaultDomainAdminACL is the ACL mailcow grants new domain admins.
+var defaultDomainAdminACL = map[string]int64{
+	"syncjobs":          0,
+	"quarantine":        1,
+	"login_as":          1,
+	"sogo_access":       1,
+	"app_passwds":       1,
+	"bcc_maps":          1,
+	"pushover":          0,
+	"filters":           1,
+	"ratelimit":         1,
+	"spam_policy":       1,
+	"extend_sender_acl": 0,
+	"unlimited_quota":   0,
+	"protocol_access":   1,
+	"smtp_ip_access":    1,
+	"alias_domains":     0,
+	"mailbox_relayhost": 1,
+	"domain_relayhost":  1,
+	"domain_desc":       0,
+}
+
+type DomainAdmin struct {
+	Username string           `json:"username"`
+	Active   int64            `json:"active"`
+	Domains  []string         `json:"selected_domains"`
+	ACL      map[string]int64 `json:"da_acl"`
+	Password string           `json:"-"`
+}
+
+// DomainAdmin returns a copy of the stored domain admin, or nil when it does
+// not exist.
+func (s *Server) DomainAdmin(username string) *DomainAdmin {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d, ok := s.domainAdmins[username]; ok {
+		copied := *d
+		copied.Domains = append([]string(nil), d.Domains...)
+		copied.ACL = map[string]int64{}
+		for acl, value := range d.ACL {
+			copied.ACL[acl] = value
+		}
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveDomainAdmin deletes a domain admin behind the provider's back.
+func (s *Server) RemoveDomainAdmin(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.domainAdmins, username)
+}
+
+func (s *Server) domainAdminHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var usernames []string
+				for username := range s.domainAdmins {
+					usernames = append(usernames, username)
+				}
+
+				domainAdmins := []DomainAdmin{}
+				for _, username := range sortedStrings(usernames) {
+					domainAdmins = append(domainAdmins, *s.domainAdmins[username])
+				}
+				return domainAdmins
+			}
+
+			if d, ok := s.domainAdmins[item]; ok {
+				return d
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			username := str(attr["username"])
+			if username == "" {
+				return *danger("username_invalid", username)
+			}
+			if _, ok := s.domainAdmins[username]; ok {
+				return *danger("object_exists", username)
+			}
+			if str(attr["password"]) == "" || str(attr["password"]) != str(attr["password2"]) {
+				return *danger("password_mismatch")
+			}
+
+			d := &DomainAdmin{Username: username, Active: 1, ACL: map[string]int64{}}
+			for acl, value := range defaultDomainAdminACL {
+				d.ACL[acl] = value
+			}
+			if failure := s.applyDomainAdmin(d, attr); failure != nil {
+				return *failure
+			}
+			s.domainAdmins[username] = d
+
+			return success("domain_admin_added", username)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			d, ok := s.domainAdmins[item]
+			if !ok {
+				return danger("access_denied")
+			}
+			if _, ok := attr["password"]; ok && str(attr["password"]) != str(attr["password2"]) {
+				return danger("password_mismatch")
+			}
+
+			return s.applyDomainAdmin(d, attr)
+		},
+		delete: func(item string) *response {
+			if _, ok := s.domainAdmins[item]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.domainAdmins, item)
+
+			return nil
+		},
+	}
+}
+
+// domainAdminACLHandler implements /api/v1/edit/da-acl, which replaces the
+// ACL of a domain admin with the listed entries.
+func (s *Server) domainAdminACLHandler() handler {
+	return handler{
+		edit: func(item string, attr map[string]interface{}) *response {
+			d, ok := s.domainAdmins[item]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			granted := map[string]bool{}
+			for _, acl := range stringList(attr["da_acl"]) {
+				if _, ok := defaultDomainAdminACL[acl]; !ok {
+					return danger("acl_invalid", acl)
+				}
+				granted[acl] = true
+			}
+
+			for acl := range defaultDomainAdminACL {
+				d.ACL[acl] = 0
+				if granted[acl] {
+					d.ACL[acl] = 1
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (s *Server) applyDomainAdmin(d *DomainAdmin, attr map[string]interface{}) *response {
+	if v, ok := attr["domains"]; ok {
+		domains := stringList(v)
+		for _, domain := range domains {
+			if _, ok := s.domains[domain]; !ok {
+				return danger("domain_invalid", domain)
+			}
+		}
+		// mailcow returns selected_domains ordered by name
+		d.Domains = sortedStrings(domains)
+	}
+	if v, ok := attr["active"]; ok {
+		d.Active = num(v)
+	}
+	if v, ok := attr["password"]; ok {
+		d.Password = str(v)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package mailcowtest
+
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Fail2ban is the netfilter configuration of mailcow. Whitelist and Blacklist
+// hold one network per line, as mailcow returns them.
+type Fail2ban struct {
+	BanTime     int64  `json:"ban_time"`
+	MaxAttempts int64  `json:"max_attempts"`
+	RetryWindow int64  `json:"retry_window"`
+	NetbanIPv4  int64  `json:"netban_ipv4"`
+	NetbanIPv6  int64  `json:"netban_ipv6"`
+	Whitelist   string `json:"whitelist"`
+	Blacklist   string `json:"blacklist"`
+}
+
+// defaultFail2ban is the configuration of a new mailcow installation.
+func defaultFail2ban() *Fail2ban {
+	return &Fail2ban{
+		BanTime:     1800,
+		MaxAttempts: 10,
+		RetryWindow: 600,
+		NetbanIPv4:  32,
+		NetbanIPv6:  128,
+	}
+}
+
+// Fail2ban returns a copy of the netfilter configuration.
+func (s *Server) Fail2ban() Fail2ban {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.fail2ban
+}
+
+// SetFail2ban replaces the netfilter configuration behind the provider's back.
+func (s *Server) SetFail2ban(config Fail2ban) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fail2ban = &config
+}
+
+// fail2banListSeparator splits whitelists and blacklists like mailcow does.
+var fail2banListSeparator = regexp.MustCompile(`[ ,;\n]`)
+
+// fail2banHandler serves the single netfilter configuration. mailcow ignores
+// the items of an edit and keeps the current value of any attribute left out.
+func (s *Server) fail2banHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			return s.fail2ban
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			updated := *s.fail2ban
+
+			clamp := func(key string, value *int64, min, max int64) {
+				if v, ok := attr[key]; ok {
+					*value = num(v)
+				}
+				if *value < min {
+					*value = min
+				}
+				if max > 0 && *value > max {
+					*value = max
+				}
+			}
+			clamp("ban_time", &updated.BanTime, 60, 0)
+			clamp("max_attempts", &updated.MaxAttempts, 1, 0)
+			clamp("retry_window", &updated.RetryWindow, 1, 0)
+			clamp("netban_ipv4", &updated.NetbanIPv4, 8, 32)
+			clamp("netban_ipv6", &updated.NetbanIPv6, 8, 128)
+
+			for key, list := range map[string]*string{"whitelist": &updated.Whitelist, "blacklist": &updated.Blacklist} {
+				v, ok := attr[key]
+				if !ok {
+					continue
+				}
+
+				var networks []string
+				for _, network := range fail2banListSeparator.Split(str(v), -1) {
+					network = strings.TrimSpace(network)
+					if network == "" {
+						continue
+					}
+					if _, _, err := net.ParseCIDR(network); err != nil && net.ParseIP(network) == nil {
+						return danger("network_host_invalid", network)
+					}
+					networks = append(networks, network)
+				}
+				*list = strings.Join(networks, "\n")
+			}
+
+			s.fail2ban = &updated
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package mailcowtest
+
+import (
+	"net"
+	"strings"
+)
+
+type ForwardingHost struct {
+	Host     string `json:"host"`
+	Source   string `json:"source"`
+	KeepSpam string `json:"keep_spam"`
+}
+
+// SetDNS makes the fake resolve hostname to ips when it is added as a
+// forwarding host.
+func (s *Server) SetDNS(hostname string, ips ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dns[hostname] = ips
+}
+
+// ForwardingHosts returns copies of the forwarding hosts added for source,
+// sorted by IP.
+func (s *Server) ForwardingHosts(source string) []ForwardingHost {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var hosts []ForwardingHost
+	for _, ip := range s.sortedForwardingHosts() {
+		if h := s.forwardingHosts[ip]; h.Source == source {
+			hosts = append(hosts, *h)
+		}
+	}
+
+	return hosts
+}
+
+// RemoveForwardingHost deletes one IP of a forwarding host behind the
+// provider's back.
+func (s *Server) RemoveForwardingHost(ip string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.forwardingHosts, ip)
+}
+
+func (s *Server) sortedForwardingHosts() []string {
+	var ips []string
+	for ip := range s.forwardingHosts {
+		ips = append(ips, ip)
+	}
+
+	return sortedStrings(ips)
+}
+
+// forwardingHostHandler stores one entry per IP, like mailcow does: a hostname
+// is resolved when it is added and each address keeps the hostname as source.
+func (s *Server) forwardingHostHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				hosts := []ForwardingHost{}
+				for _, ip := range s.sortedForwardingHosts() {
+					hosts = append(hosts, *s.forwardingHosts[ip])
+				}
+				return hosts
+			}
+
+			if h, ok := s.forwardingHosts[item]; ok {
+				return h
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			hostname := strings.TrimSpace(str(attr["hostname"]))
+
+			var ips []string
+			if _, _, err := net.ParseCIDR(hostname); err == nil || net.ParseIP(hostname) != nil {
+				ips = []string{hostname}
+			} else {
+				ips = s.dns[hostname]
+			}
+			if len(ips) == 0 {
+				return *danger("invalid_host", hostname)
+			}
+
+			keepSpam := "yes"
+			if num(attr["filter_spam"]) == 1 {
+				keepSpam = "no"
+			}
+			for _, ip := range ips {
+				s.forwardingHosts[ip] = &ForwardingHost{Host: ip, Source: hostname, KeepSpam: keepSpam}
+			}
+
+			return success("forwarding_host_added", strings.Join(ips, ", "))
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			h, ok := s.forwardingHosts[item]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			if v, ok := attr["keep_spam"]; ok {
+				h.KeepSpam = str(v)
+			}
+
+			return nil
+		},
+		delete: func(item string) *response {
+			if _, ok := s.forwardingHosts[item]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.forwardingHosts, item)
+
+			return nil
+		},
+	}
+}
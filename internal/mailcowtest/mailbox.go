@@ -0,0 +1,149 @@
+package mailcowtest
+
+import "strconv"
+
+type Mailbox struct {
+	Username   string            `json:"username"`
+	LocalPart  string            `json:"local_part"`
+	Domain     string            `json:"domain"`
+	Name       string            `json:"name"`
+	Active     int64             `json:"active"`
+	Quota      int64             `json:"quota"`
+	Attributes MailboxAttributes `json:"attributes"`
+	Password   string            `json:"-"`
+}
+
+type MailboxAttributes struct {
+	ForcePasswordUpdate string `json:"force_pw_update"`
+}
+
+// Mailbox returns a copy of the stored mailbox, or nil when it does not exist.
+func (s *Server) Mailbox(username string) *Mailbox {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, ok := s.mailboxes[username]; ok {
+		copied := *m
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveMailbox deletes a mailbox behind the provider's back.
+func (s *Server) RemoveMailbox(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.mailboxes, username)
+}
+
+func (s *Server) mailboxHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var usernames []string
+				for username := range s.mailboxes {
+					usernames = append(usernames, username)
+				}
+				if len(usernames) == 0 {
+					// mailcow answers an empty list with {}
+					return nil
+				}
+
+				mailboxes := []Mailbox{}
+				for _, username := range sortedStrings(usernames) {
+					mailboxes = append(mailboxes, *s.mailboxes[username])
+				}
+				return mailboxes
+			}
+
+			if m, ok := s.mailboxes[item]; ok {
+				return m
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			localPart, domain := str(attr["local_part"]), str(attr["domain"])
+			username := localPart + "@" + domain
+			if _, ok := s.domains[domain]; !ok {
+				return *danger("domain_not_found", domain)
+			}
+			if s.addressTaken(username) {
+				return *danger("object_exists", username)
+			}
+			if str(attr["password"]) == "" || str(attr["password"]) != str(attr["password2"]) {
+				return *danger("password_mismatch")
+			}
+
+			m := &Mailbox{
+				Username:   username,
+				LocalPart:  localPart,
+				Domain:     domain,
+				Active:     1,
+				Attributes: MailboxAttributes{ForcePasswordUpdate: "0"},
+			}
+			applyMailbox(m, attr)
+			s.mailboxes[username] = m
+
+			return success("mailbox_added", username)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			m, ok := s.mailboxes[item]
+			if !ok {
+				return danger("access_denied")
+			}
+			if _, ok := attr["password"]; ok && str(attr["password"]) != str(attr["password2"]) {
+				return danger("password_mismatch")
+			}
+
+			applyMailbox(m, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			if _, ok := s.mailboxes[item]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.mailboxes, item)
+			delete(s.ratelimits, "mailbox/"+item)
+			for id, j := range s.syncJobs {
+				if j.Username == item {
+					delete(s.syncJobs, id)
+				}
+			}
+			for id, a := range s.appPasswords {
+				if a.Mailbox == item {
+					delete(s.appPasswords, id)
+				}
+			}
+			for id, f := range s.mailboxFilters {
+				if f.Username == item {
+					delete(s.mailboxFilters, id)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func applyMailbox(m *Mailbox, attr map[string]interface{}) {
+	if v, ok := attr["name"]; ok {
+		m.Name = str(v)
+	}
+	if v, ok := attr["quota"]; ok {
+		m.Quota = num(v) * 1024 * 1024
+	}
+	if v, ok := attr["active"]; ok {
+		m.Active = num(v)
+	}
+	if v, ok := attr["password"]; ok {
+		m.Password = str(v)
+	}
+	if v, ok := attr["force_pw_update"]; ok {
+		m.Attributes.ForcePasswordUpdate = strconv.FormatInt(num(v), 10)
+	}
+}
@@ -0,0 +1,133 @@
+package mailcowtest
+
+import "strconv"
+
+type MailboxFilter struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	Description string `json:"script_desc"`
+	ScriptName  string `json:"script_name"`
+	ScriptData  string `json:"script_data"`
+	FilterType  string `json:"filter_type"`
+}
+
+// MailboxFilter returns a copy of the stored filter, or nil when it does not
+// exist.
+func (s *Server) MailboxFilter(id int64) *MailboxFilter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.mailboxFilters[id]; ok {
+		copied := *f
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveMailboxFilter deletes a filter behind the provider's back.
+func (s *Server) RemoveMailboxFilter(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.mailboxFilters, id)
+}
+
+// mailboxFiltersHandler serves /api/v1/get/filters/{mailbox|all}.
+func (s *Server) mailboxFiltersHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			var ids []int64
+			for id, f := range s.mailboxFilters {
+				if item == "all" || f.Username == item {
+					ids = append(ids, id)
+				}
+			}
+
+			filters := []MailboxFilter{}
+			for _, id := range sortedIDs(ids) {
+				filters = append(filters, *s.mailboxFilters[id])
+			}
+			return filters
+		},
+	}
+}
+
+func (s *Server) mailboxFilterHandler() handler {
+	return handler{
+		add: func(attr map[string]interface{}) response {
+			username := str(attr["username"])
+			if _, ok := s.mailboxes[username]; !ok {
+				return *danger("access_denied")
+			}
+			if str(attr["script_data"]) == "" {
+				return *danger("script_empty")
+			}
+			if t := str(attr["filter_type"]); t != "prefilter" && t != "postfilter" {
+				return *danger("filter_type")
+			}
+
+			// mailcow does not return the ID of the new filter
+			f := &MailboxFilter{ID: s.newID("sieve_filters"), Username: username}
+			applyMailboxFilter(f, attr)
+			s.mailboxFilters[f.ID] = f
+			s.deactivateOtherFilters(f)
+
+			return success("mailbox_modified", username)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			f, ok := s.mailboxFilters[id]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			applyMailboxFilter(f, attr)
+			s.deactivateOtherFilters(f)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if _, ok := s.mailboxFilters[id]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.mailboxFilters, id)
+
+			return nil
+		},
+	}
+}
+
+// deactivateOtherFilters keeps f the only active filter of its type in the
+// mailbox, as mailcow does.
+func (s *Server) deactivateOtherFilters(f *MailboxFilter) {
+	if f.ScriptName != "active" {
+		return
+	}
+
+	for _, other := range s.mailboxFilters {
+		if other != f && other.Username == f.Username && other.FilterType == f.FilterType {
+			other.ScriptName = "inactive"
+		}
+	}
+}
+
+func applyMailboxFilter(f *MailboxFilter, attr map[string]interface{}) {
+	if v, ok := attr["script_desc"]; ok {
+		f.Description = str(v)
+	}
+	if v, ok := attr["script_data"]; ok {
+		f.ScriptData = str(v)
+	}
+	if v, ok := attr["filter_type"]; ok {
+		f.FilterType = str(v)
+	}
+	if v, ok := attr["active"]; ok {
+		f.ScriptName = "inactive"
+		if num(v) == 1 {
+			f.ScriptName = "active"
+		}
+	}
+}
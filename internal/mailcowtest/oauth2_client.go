@@ -0,0 +1,116 @@
+package mailcowtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+)
+
+type OAuth2Client struct {
+	ID           int64  `json:"id"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RedirectURI  string `json:"redirect_uri"`
+	Scope        string `json:"scope"`
+}
+
+// OAuth2Client returns a copy of the stored OAuth2 client, or nil when it does
+// not exist.
+func (s *Server) OAuth2Client(id int64) *OAuth2Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.oauth2Clients[id]; ok {
+		copied := *c
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveOAuth2Client deletes an OAuth2 client behind the provider's back.
+func (s *Server) RemoveOAuth2Client(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.oauth2Clients, id)
+}
+
+// randomHex returns n random bytes hex encoded, like the client IDs and
+// secrets mailcow generates.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+func (s *Server) oauth2ClientHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var ids []int64
+				for id := range s.oauth2Clients {
+					ids = append(ids, id)
+				}
+
+				clients := []OAuth2Client{}
+				for _, id := range sortedIDs(ids) {
+					clients = append(clients, *s.oauth2Clients[id])
+				}
+				return clients
+			}
+
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if c, ok := s.oauth2Clients[id]; ok {
+				return c
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			redirectURI := str(attr["redirect_uri"])
+			if redirectURI == "" {
+				return *danger("redirect_uri_empty")
+			}
+
+			// mailcow does not return the ID of the new client
+			c := &OAuth2Client{
+				ID:           s.newID("oauth_clients"),
+				ClientID:     randomHex(12),
+				ClientSecret: randomHex(12),
+				RedirectURI:  redirectURI,
+				Scope:        "profile",
+			}
+			s.oauth2Clients[c.ID] = c
+
+			return success("object_modified", c.ClientID)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			c, ok := s.oauth2Clients[id]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			if v, ok := attr["redirect_uri"]; ok && str(v) != "" {
+				c.RedirectURI = str(v)
+			}
+			if num(attr["renew_secret"]) == 1 {
+				c.ClientSecret = randomHex(12)
+			}
+
+			return nil
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if _, ok := s.oauth2Clients[id]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.oauth2Clients, id)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package mailcowtest
+
+type Ratelimit struct {
+	Value string `json:"value"`
+	Frame string `json:"frame"`
+}
+
+// DomainRatelimit returns a copy of the rate limit of a domain, or nil when it
+// has none.
+func (s *Server) DomainRatelimit(domain string) *Ratelimit {
+	return s.ratelimit("domain", domain)
+}
+
+// MailboxRatelimit returns a copy of the rate limit of a mailbox, or nil when
+// it has none.
+func (s *Server) MailboxRatelimit(username string) *Ratelimit {
+	return s.ratelimit("mailbox", username)
+}
+
+// SetDomainRatelimit changes the rate limit of a domain behind the provider's
+// back.
+func (s *Server) SetDomainRatelimit(domain, value, frame string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ratelimits["domain/"+domain] = &Ratelimit{Value: value, Frame: frame}
+}
+
+// RemoveRatelimit clears the rate limit of a domain or mailbox behind the
+// provider's back.
+func (s *Server) RemoveRatelimit(object string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.ratelimits, "domain/"+object)
+	delete(s.ratelimits, "mailbox/"+object)
+}
+
+func (s *Server) ratelimit(kind, object string) *Ratelimit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rl, ok := s.ratelimits[kind+"/"+object]; ok {
+		copied := *rl
+		return &copied
+	}
+
+	return nil
+}
+
+// ratelimitHandler serves rl-domain or rl-mbox. mailcow keeps rate limits in
+// Redis rather than as objects, so they are only read and edited, and an empty
+// value clears them.
+func (s *Server) ratelimitHandler(kind string) handler {
+	exists := func(object string) bool {
+		if kind == "domain" {
+			_, ok := s.domains[object]
+			return ok
+		}
+		_, ok := s.mailboxes[object]
+		return ok
+	}
+
+	return handler{
+		get: func(item string) interface{} {
+			if rl, ok := s.ratelimits[kind+"/"+item]; ok {
+				return rl
+			}
+
+			// mailcow answers false for objects without a rate limit
+			return false
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			if !exists(item) {
+				return danger("access_denied")
+			}
+
+			value, frame := str(attr["rl_value"]), str(attr["rl_frame"])
+			if frame != "s" && frame != "m" && frame != "h" && frame != "d" {
+				return danger("rl_timeframe")
+			}
+
+			if value == "" || num(value) == 0 {
+				delete(s.ratelimits, kind+"/"+item)
+				return nil
+			}
+			if num(value) < 0 {
+				return danger("rl_value")
+			}
+
+			s.ratelimits[kind+"/"+item] = &Ratelimit{Value: value, Frame: frame}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,109 @@
+package mailcowtest
+
+import "strconv"
+
+type RecipientMap struct {
+	ID     int64  `json:"id"`
+	Old    string `json:"recipient_map_old"`
+	New    string `json:"recipient_map_new"`
+	Active int64  `json:"active"`
+}
+
+// RecipientMap returns a copy of the stored recipient map, or nil when it does
+// not exist.
+func (s *Server) RecipientMap(id int64) *RecipientMap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, ok := s.recipientMaps[id]; ok {
+		copied := *m
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveRecipientMap deletes a recipient map behind the provider's back.
+func (s *Server) RemoveRecipientMap(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.recipientMaps, id)
+}
+
+func (s *Server) recipientMapHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var ids []int64
+				for id := range s.recipientMaps {
+					ids = append(ids, id)
+				}
+
+				recipientMaps := []RecipientMap{}
+				for _, id := range sortedIDs(ids) {
+					recipientMaps = append(recipientMaps, *s.recipientMaps[id])
+				}
+				return recipientMaps
+			}
+
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if m, ok := s.recipientMaps[id]; ok {
+				return m
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			old, new := str(attr["recipient_map_old"]), str(attr["recipient_map_new"])
+			if old == "" || new == "" {
+				return *danger("invalid_recipient_map_old", old)
+			}
+			for _, m := range s.recipientMaps {
+				if m.Old == old {
+					return *danger("recipient_map_entry_exists", old)
+				}
+			}
+
+			// Unlike BCC maps, the response carries no ID of the new entry
+			m := &RecipientMap{ID: s.newID("recipient_maps"), Active: 1}
+			applyRecipientMap(m, attr)
+			s.recipientMaps[m.ID] = m
+
+			return success("recipient_map_entry_saved", old)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			m, ok := s.recipientMaps[id]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			applyRecipientMap(m, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if _, ok := s.recipientMaps[id]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.recipientMaps, id)
+
+			return nil
+		},
+	}
+}
+
+func applyRecipientMap(m *RecipientMap, attr map[string]interface{}) {
+	if v, ok := attr["recipient_map_old"]; ok {
+		m.Old = str(v)
+	}
+	if v, ok := attr["recipient_map_new"]; ok {
+		m.New = str(v)
+	}
+	if v, ok := attr["active"]; ok {
+		m.Active = num(v)
+	}
+}
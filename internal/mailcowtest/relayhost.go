@@ -0,0 +1,117 @@
+package mailcowtest
+
+import "strconv"
+
+type Relayhost struct {
+	ID       int64  `json:"id"`
+	Hostname string `json:"hostname"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Active   int64  `json:"active"`
+}
+
+// Relayhost returns a copy of the stored relayhost, or nil when it does not
+// exist.
+func (s *Server) Relayhost(id int64) *Relayhost {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.relayhosts[id]; ok {
+		copied := *r
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveRelayhost deletes a relayhost behind the provider's back.
+func (s *Server) RemoveRelayhost(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.relayhosts, id)
+}
+
+func (s *Server) relayhostHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var ids []int64
+				for id := range s.relayhosts {
+					ids = append(ids, id)
+				}
+				if len(ids) == 0 {
+					// mailcow answers an empty list with {}
+					return nil
+				}
+
+				relayhosts := []Relayhost{}
+				for _, id := range sortedIDs(ids) {
+					relayhosts = append(relayhosts, *s.relayhosts[id])
+				}
+				return relayhosts
+			}
+
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if r, ok := s.relayhosts[id]; ok {
+				return r
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			hostname := str(attr["hostname"])
+			if hostname == "" {
+				return *danger("invalid_host", hostname)
+			}
+
+			// mailcow does not return the ID of the new relayhost
+			r := &Relayhost{ID: s.newID("relayhost"), Active: 1}
+			applyRelayhost(r, attr)
+			s.relayhosts[r.ID] = r
+
+			return success("relayhost_added", hostname)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			r, ok := s.relayhosts[id]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			applyRelayhost(r, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if _, ok := s.relayhosts[id]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.relayhosts, id)
+			for _, d := range s.domains {
+				if d.RelayHost == item {
+					d.RelayHost = "0"
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func applyRelayhost(r *Relayhost, attr map[string]interface{}) {
+	if v, ok := attr["hostname"]; ok {
+		r.Hostname = str(v)
+	}
+	if v, ok := attr["username"]; ok {
+		r.Username = str(v)
+	}
+	if v, ok := attr["password"]; ok {
+		r.Password = str(v)
+	}
+	if v, ok := attr["active"]; ok {
+		r.Active = num(v)
+	}
+}
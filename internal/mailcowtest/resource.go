@@ -0,0 +1,122 @@
+package mailcowtest
+
+import "regexp"
+
+type Resource struct {
+	Name             string `json:"name"`
+	LocalPart        string `json:"local_part"`
+	Domain           string `json:"domain"`
+	Description      string `json:"description"`
+	Kind             string `json:"kind"`
+	MultipleBookings int64  `json:"multiple_bookings"`
+	Active           int64  `json:"active"`
+}
+
+// nonAlphanumeric matches what mailcow strips from a description to derive
+// the local part of a resource name.
+var nonAlphanumeric = regexp.MustCompile(`[^\da-zA-Z]`)
+
+// Resource returns a copy of the stored resource, or nil when it does not
+// exist.
+func (s *Server) Resource(name string) *Resource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.resources[name]; ok {
+		copied := *r
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveResource deletes a resource behind the provider's back.
+func (s *Server) RemoveResource(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.resources, name)
+}
+
+func (s *Server) resourceHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var names []string
+				for name := range s.resources {
+					names = append(names, name)
+				}
+
+				resources := []Resource{}
+				for _, name := range sortedStrings(names) {
+					resources = append(resources, *s.resources[name])
+				}
+				return resources
+			}
+
+			if r, ok := s.resources[item]; ok {
+				return r
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			description, domain, kind := str(attr["description"]), str(attr["domain"]), str(attr["kind"])
+			localPart := nonAlphanumeric.ReplaceAllString(description, "")
+			if localPart == "" {
+				return *danger("description_invalid", "resource")
+			}
+			if _, ok := s.domains[domain]; !ok {
+				return *danger("domain_not_found", domain)
+			}
+			if kind != "location" && kind != "group" && kind != "thing" {
+				return *danger("resource_invalid", localPart)
+			}
+
+			name := localPart + "@" + domain
+			if s.addressTaken(name) {
+				return *danger("object_exists", name)
+			}
+
+			r := &Resource{Name: name, LocalPart: localPart, Domain: domain, Active: 1}
+			applyResource(r, attr)
+			s.resources[name] = r
+
+			return success("resource_added", name)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			r, ok := s.resources[item]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			applyResource(r, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			if _, ok := s.resources[item]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.resources, item)
+
+			return nil
+		},
+	}
+}
+
+func applyResource(r *Resource, attr map[string]interface{}) {
+	if v, ok := attr["description"]; ok {
+		r.Description = str(v)
+	}
+	if v, ok := attr["kind"]; ok {
+		r.Kind = str(v)
+	}
+	if v, ok := attr["multiple_bookings"]; ok {
+		r.MultipleBookings = num(v)
+	}
+	if v, ok := attr["active"]; ok {
+		r.Active = num(v)
+	}
+}
@@ -0,0 +1,323 @@
+// Package mailcowtest provides an in-memory fake of the mailcow API for tests.
+//
+// The fake implements the /api/v1/{get,add,edit,delete} endpoints closely
+// enough for the provider to be exercised end-to-end without a mailcow
+// instance. Each object type registers a handler in its own file.
+package mailcowtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Server is a fake mailcow API backed by in-memory state. Requests must carry
+// APIKey in the X-API-Key header.
+type Server struct {
+	*httptest.Server
+	APIKey string
+
+	mu       sync.Mutex
+	handlers map[string]handler
+
+	domains         map[string]*Domain
+	aliases         map[int64]*Alias
+	aliasDomains    map[string]*AliasDomain
+	appPasswords    map[int64]*AppPassword
+	bccMaps         map[int64]*BCCMap
+	dkimKeys        map[string]*DKIMKey
+	domainAdmins    map[string]*DomainAdmin
+	fail2ban        *Fail2ban
+	forwardingHosts map[string]*ForwardingHost
+	mailboxes       map[string]*Mailbox
+	mailboxFilters  map[int64]*MailboxFilter
+	oauth2Clients   map[int64]*OAuth2Client
+	ratelimits      map[string]*Ratelimit
+	recipientMaps   map[int64]*RecipientMap
+	relayhosts      map[int64]*Relayhost
+	resources       map[string]*Resource
+	spamPolicies    map[int64]*SpamPolicy
+	syncJobs        map[int64]*SyncJob
+	tlsPolicies     map[int64]*TLSPolicy
+	transports      map[int64]*Transport
+	lastIDs         map[string]int64
+
+	// dns resolves hostnames added as forwarding hosts
+	dns map[string][]string
+}
+
+// handler implements the endpoints of one object type, such as "domain". Any
+// function may be nil when mailcow has no such endpoint. edit and delete are
+// called once per item and return nil on success.
+type handler struct {
+	get    func(item string) interface{}
+	add    func(attr map[string]interface{}) response
+	edit   func(item string, attr map[string]interface{}) *response
+	delete func(item string) *response
+}
+
+// NewServer starts a fake mailcow API accepting apiKey. Callers must Close it.
+func NewServer(apiKey string) *Server {
+	s := &Server{
+		APIKey:          apiKey,
+		domains:         map[string]*Domain{},
+		aliases:         map[int64]*Alias{},
+		aliasDomains:    map[string]*AliasDomain{},
+		appPasswords:    map[int64]*AppPassword{},
+		bccMaps:         map[int64]*BCCMap{},
+		dkimKeys:        map[string]*DKIMKey{},
+		domainAdmins:    map[string]*DomainAdmin{},
+		fail2ban:        defaultFail2ban(),
+		forwardingHosts: map[string]*ForwardingHost{},
+		mailboxes:       map[string]*Mailbox{},
+		mailboxFilters:  map[int64]*MailboxFilter{},
+		oauth2Clients:   map[int64]*OAuth2Client{},
+		ratelimits:      map[string]*Ratelimit{},
+		recipientMaps:   map[int64]*RecipientMap{},
+		relayhosts:      map[int64]*Relayhost{},
+		resources:       map[string]*Resource{},
+		spamPolicies:    map[int64]*SpamPolicy{},
+		syncJobs:        map[int64]*SyncJob{},
+		tlsPolicies:     map[int64]*TLSPolicy{},
+		transports:      map[int64]*Transport{},
+		lastIDs:         map[string]int64{},
+		dns:             map[string][]string{},
+	}
+
+	s.handlers = map[string]handler{
+		"alias":             s.aliasHandler(),
+		"alias-domain":      s.aliasDomainHandler(),
+		"app-passwd":        s.appPasswordHandler(),
+		"bcc":               s.bccMapHandler(),
+		"dkim":              s.dkimHandler(),
+		"dkim_duplicate":    s.dkimDuplicateHandler(),
+		"da-acl":            s.domainAdminACLHandler(),
+		"domain":            s.domainHandler(),
+		"domain-admin":      s.domainAdminHandler(),
+		"domain-policy":     s.spamPolicyHandler("domain", "domain"),
+		"fail2ban":          s.fail2banHandler(),
+		"filter":            s.mailboxFilterHandler(),
+		"filters":           s.mailboxFiltersHandler(),
+		"fwdhost":           s.forwardingHostHandler(),
+		"mailbox":           s.mailboxHandler(),
+		"mailbox-policy":    s.spamPolicyHandler("mailbox", "username"),
+		"oauth2-client":     s.oauth2ClientHandler(),
+		"policy_bl_domain":  s.spamPolicyListHandler("bl", "domain"),
+		"policy_bl_mailbox": s.spamPolicyListHandler("bl", "mailbox"),
+		"policy_wl_domain":  s.spamPolicyListHandler("wl", "domain"),
+		"policy_wl_mailbox": s.spamPolicyListHandler("wl", "mailbox"),
+		"recipient_map":     s.recipientMapHandler(),
+		"rl-domain":         s.ratelimitHandler("domain"),
+		"rl-mbox":           s.ratelimitHandler("mailbox"),
+		"relayhost":         s.relayhostHandler(),
+		"resource":          s.resourceHandler(),
+		"syncjob":           s.syncJobHandler(),
+		"syncjobs":          s.syncJobsHandler(),
+		"tls-policy-map":    s.tlsPolicyHandler(),
+		"transport":         s.transportHandler(),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+type response struct {
+	Type    string        `json:"type"`
+	Log     []interface{} `json:"log"`
+	Message []interface{} `json:"msg"`
+}
+
+func success(msg ...interface{}) response {
+	return response{Type: "success", Log: []interface{}{}, Message: msg}
+}
+
+func danger(msg ...interface{}) *response {
+	return &response{Type: "danger", Log: []interface{}{}, Message: msg}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-API-Key") != s.APIKey {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"type": "error", "msg": "authentication failed"})
+		return
+	}
+
+	// /api/v1/{action}/{object}[/{item}...]
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/api/v1/"), "/", 3)
+	if len(parts) < 2 {
+		writeJSON(w, http.StatusNotFound, danger("route_not_found"))
+		return
+	}
+	action, object := parts[0], parts[1]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := s.handlers[object]
+
+	if action == "get" && r.Method == http.MethodGet && h.get != nil {
+		item := ""
+		if len(parts) == 3 {
+			item = parts[2]
+		}
+		if value := h.get(item); value != nil {
+			writeJSON(w, http.StatusOK, value)
+			return
+		}
+
+		// mailcow answers lookups of missing objects with an empty object
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusNotFound, danger("route_not_found"))
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, danger("request_invalid"))
+		return
+	}
+
+	var result response
+	switch {
+	case action == "add" && h.add != nil:
+		var attr map[string]interface{}
+		if json.Unmarshal(body, &attr) != nil {
+			writeJSON(w, http.StatusOK, []*response{danger("request_invalid")})
+			return
+		}
+		result = h.add(attr)
+	case action == "edit" && h.edit != nil:
+		var edit struct {
+			Attr  map[string]interface{} `json:"attr"`
+			Items interface{}            `json:"items"`
+		}
+		if json.Unmarshal(body, &edit) != nil {
+			writeJSON(w, http.StatusOK, []*response{danger("request_invalid")})
+			return
+		}
+		items := stringList(edit.Items)
+		result = success(object+"_modified", strings.Join(items, ", "))
+		for _, item := range items {
+			if failure := h.edit(item, edit.Attr); failure != nil {
+				result = *failure
+				break
+			}
+		}
+	case action == "delete" && h.delete != nil:
+		var items interface{}
+		if json.Unmarshal(body, &items) != nil {
+			writeJSON(w, http.StatusOK, []*response{danger("request_invalid")})
+			return
+		}
+		list := stringList(items)
+		result = success(object+"_removed", strings.Join(list, ", "))
+		for _, item := range list {
+			if failure := h.delete(item); failure != nil {
+				result = *failure
+				break
+			}
+		}
+	default:
+		writeJSON(w, http.StatusNotFound, danger("route_not_found"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, []response{result})
+}
+
+// newID returns the next auto-increment ID of table, starting at 1.
+func (s *Server) newID(table string) int64 {
+	s.lastIDs[table]++
+
+	return s.lastIDs[table]
+}
+
+func (s *Server) addressTaken(address string) bool {
+	if _, ok := s.mailboxes[address]; ok {
+		return true
+	}
+	if _, ok := s.resources[address]; ok {
+		return true
+	}
+
+	for _, a := range s.aliases {
+		if a.Address == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// str and num accept both JSON strings and numbers, like the mailcow PHP API.
+func str(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func num(v interface{}) int64 {
+	switch value := v.(type) {
+	case float64:
+		return int64(value)
+	case bool:
+		if value {
+			return 1
+		}
+		return 0
+	default:
+		n, _ := strconv.ParseInt(str(value), 10, 64)
+		return n
+	}
+}
+
+// stringList accepts a JSON array or a single value, as mailcow does for items.
+func stringList(items interface{}) []string {
+	list, ok := items.([]interface{})
+	if !ok {
+		if items == nil {
+			return nil
+		}
+		return []string{str(items)}
+	}
+
+	var values []string
+	for _, item := range list {
+		values = append(values, str(item))
+	}
+
+	return values
+}
+
+func sortedStrings(keys []string) []string {
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedIDs(ids []int64) []int64 {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
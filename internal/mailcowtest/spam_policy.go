@@ -0,0 +1,113 @@
+package mailcowtest
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// validPolicyFrom matches the sender patterns mailcow accepts in whitelists
+// and blacklists, such as "*@example.org", "user@example.org" or "example.org".
+var validPolicyFrom = regexp.MustCompile(`^(\*|[^@\s*]+)?@?[^@\s]+$`)
+
+type SpamPolicy struct {
+	ID     int64  `json:"prefid"`
+	Object string `json:"object"`
+	From   string `json:"value"`
+	List   string `json:"-"`
+	Scope  string `json:"-"`
+}
+
+// SpamPolicy returns a copy of the stored policy, or nil when it does not
+// exist.
+func (s *Server) SpamPolicy(id int64) *SpamPolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.spamPolicies[id]; ok {
+		copied := *p
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveSpamPolicy deletes a policy behind the provider's back.
+func (s *Server) RemoveSpamPolicy(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.spamPolicies, id)
+}
+
+// spamPolicyListHandler implements /api/v1/get/policy_{list}_{scope}/{object}.
+func (s *Server) spamPolicyListHandler(list, scope string) handler {
+	return handler{
+		get: func(item string) interface{} {
+			var ids []int64
+			for id, p := range s.spamPolicies {
+				if p.List == list && p.Scope == scope && p.Object == item {
+					ids = append(ids, id)
+				}
+			}
+
+			// mailcow answers with {} rather than [] when the list is empty
+			if len(ids) == 0 {
+				return nil
+			}
+
+			policies := []SpamPolicy{}
+			for _, id := range sortedIDs(ids) {
+				policies = append(policies, *s.spamPolicies[id])
+			}
+			return policies
+		},
+	}
+}
+
+// spamPolicyHandler implements /api/v1/{add,delete}/{scope}-policy, where the
+// object is named by objectAttr.
+func (s *Server) spamPolicyHandler(scope, objectAttr string) handler {
+	return handler{
+		add: func(attr map[string]interface{}) response {
+			object, list, from := str(attr[objectAttr]), str(attr["object_list"]), str(attr["object_from"])
+
+			exists := false
+			switch scope {
+			case "domain":
+				_, exists = s.domains[object]
+			case "mailbox":
+				_, exists = s.mailboxes[object]
+			}
+			if !exists {
+				return *danger("access_denied")
+			}
+			if list != "wl" && list != "bl" {
+				return *danger("access_denied")
+			}
+			if !validPolicyFrom.MatchString(from) {
+				return *danger("policy_list_from_invalid")
+			}
+			for _, p := range s.spamPolicies {
+				if p.Scope == scope && p.Object == object && p.From == from {
+					return *danger("policy_list_from_exists")
+				}
+			}
+
+			id := s.newID("filterconf")
+			s.spamPolicies[id] = &SpamPolicy{ID: id, Object: object, From: from, List: list, Scope: scope}
+
+			return success(scope+"_modified", object)
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			p, ok := s.spamPolicies[id]
+			if !ok || p.Scope != scope {
+				return danger("access_denied")
+			}
+
+			delete(s.spamPolicies, id)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,184 @@
+package mailcowtest
+
+import (
+	"strconv"
+	"strings"
+)
+
+type SyncJob struct {
+	ID           int64   `json:"id"`
+	Username     string  `json:"user2"`
+	Host         string  `json:"host1"`
+	Port         int64   `json:"port1"`
+	Encryption   string  `json:"enc1"`
+	User         string  `json:"user1"`
+	Password     string  `json:"password1"`
+	Interval     int64   `json:"mins_interval"`
+	DeleteSource int64   `json:"delete1"`
+	Exclude      string  `json:"exclude"`
+	Subfolder    string  `json:"subfolder2"`
+	CustomParams string  `json:"custom_params"`
+	Active       int64   `json:"active"`
+	IsRunning    int64   `json:"is_running"`
+	LastRun      *string `json:"last_run"`
+	Success      *int64  `json:"success"`
+	ExitStatus   *string `json:"exit_status"`
+}
+
+// SyncJob returns a copy of the stored sync job, or nil when it does not exist.
+func (s *Server) SyncJob(id int64) *SyncJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if j, ok := s.syncJobs[id]; ok {
+		copied := *j
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveSyncJob deletes a sync job behind the provider's back.
+func (s *Server) RemoveSyncJob(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.syncJobs, id)
+}
+
+// FinishSyncJob records a run of the sync job, as imapsync would after it
+// exits with exitStatus.
+func (s *Server) FinishSyncJob(id int64, lastRun, exitStatus string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if j, ok := s.syncJobs[id]; ok {
+		success := int64(0)
+		if exitStatus == "EX_OK" {
+			success = 1
+		}
+
+		j.IsRunning = 0
+		j.LastRun = &lastRun
+		j.Success = &success
+		j.ExitStatus = &exitStatus
+	}
+}
+
+// syncJobsHandler implements /api/v1/get/syncjobs/all[/no_log], the only way
+// mailcow lists sync jobs.
+func (s *Server) syncJobsHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if !strings.HasPrefix(item, "all") {
+				return nil
+			}
+
+			var ids []int64
+			for id := range s.syncJobs {
+				ids = append(ids, id)
+			}
+			if len(ids) == 0 {
+				// mailcow answers an empty list with {}
+				return nil
+			}
+
+			syncJobs := []SyncJob{}
+			for _, id := range sortedIDs(ids) {
+				syncJobs = append(syncJobs, *s.syncJobs[id])
+			}
+			return syncJobs
+		},
+	}
+}
+
+func (s *Server) syncJobHandler() handler {
+	return handler{
+		add: func(attr map[string]interface{}) response {
+			username := str(attr["username"])
+			if _, ok := s.mailboxes[username]; !ok {
+				return *danger("access_denied")
+			}
+			if str(attr["host1"]) == "" || str(attr["user1"]) == "" || str(attr["password1"]) == "" {
+				return *danger("syncjob_invalid", username)
+			}
+
+			// mailcow does not return the ID of the new sync job
+			j := &SyncJob{ID: s.newID("imapsync"), Username: username, Port: 993, Encryption: "TLS", Interval: 20, Active: 1}
+			if failure := applySyncJob(j, attr); failure != nil {
+				return *failure
+			}
+			s.syncJobs[j.ID] = j
+
+			return success("mailbox_modified", username)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			j, ok := s.syncJobs[id]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			return applySyncJob(j, attr)
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if _, ok := s.syncJobs[id]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.syncJobs, id)
+
+			return nil
+		},
+	}
+}
+
+func applySyncJob(j *SyncJob, attr map[string]interface{}) *response {
+	if v, ok := attr["enc1"]; ok {
+		switch str(v) {
+		case "SSL", "TLS", "PLAIN":
+			j.Encryption = str(v)
+		default:
+			return danger("invalid_enc", str(v))
+		}
+	}
+	if v, ok := attr["port1"]; ok {
+		if num(v) < 1 || num(v) > 65535 {
+			return danger("port_invalid", str(v))
+		}
+		j.Port = num(v)
+	}
+	if v, ok := attr["mins_interval"]; ok {
+		if num(v) < 1 || num(v) > 43800 {
+			return danger("mins_interval_invalid")
+		}
+		j.Interval = num(v)
+	}
+	if v, ok := attr["host1"]; ok {
+		j.Host = str(v)
+	}
+	if v, ok := attr["user1"]; ok {
+		j.User = str(v)
+	}
+	if v, ok := attr["password1"]; ok {
+		j.Password = str(v)
+	}
+	if v, ok := attr["delete1"]; ok {
+		j.DeleteSource = num(v)
+	}
+	if v, ok := attr["exclude"]; ok {
+		j.Exclude = str(v)
+	}
+	if v, ok := attr["subfolder2"]; ok {
+		j.Subfolder = str(v)
+	}
+	if v, ok := attr["custom_params"]; ok {
+		j.CustomParams = str(v)
+	}
+	if v, ok := attr["active"]; ok {
+		j.Active = num(v)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package mailcowtest
+
+import "strconv"
+
+type TLSPolicy struct {
+	ID         int64  `json:"id"`
+	Dest       string `json:"dest"`
+	Policy     string `json:"policy"`
+	Parameters string `json:"parameters"`
+	Active     int64  `json:"active"`
+}
+
+var tlsPolicies = map[string]bool{
+	"none": true, "may": true, "encrypt": true, "dane": true,
+	"dane-only": true, "fingerprint": true, "verify": true, "secure": true,
+}
+
+// TLSPolicy returns a copy of the stored TLS policy map entry, or nil when it
+// does not exist.
+func (s *Server) TLSPolicy(id int64) *TLSPolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.tlsPolicies[id]; ok {
+		copied := *p
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveTLSPolicy deletes a TLS policy map entry behind the provider's back.
+func (s *Server) RemoveTLSPolicy(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tlsPolicies, id)
+}
+
+func (s *Server) tlsPolicyHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var ids []int64
+				for id := range s.tlsPolicies {
+					ids = append(ids, id)
+				}
+
+				policies := []TLSPolicy{}
+				for _, id := range sortedIDs(ids) {
+					policies = append(policies, *s.tlsPolicies[id])
+				}
+				return policies
+			}
+
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if p, ok := s.tlsPolicies[id]; ok {
+				return p
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			dest, policy := str(attr["dest"]), str(attr["policy"])
+			if dest == "" {
+				return *danger("invalid_destination", dest)
+			}
+			if !tlsPolicies[policy] {
+				return *danger("invalid_tls_policy", policy)
+			}
+			for _, p := range s.tlsPolicies {
+				if p.Dest == dest {
+					return *danger("tls_policy_map_entry_exists", dest)
+				}
+			}
+
+			// mailcow does not return the ID of the new entry
+			p := &TLSPolicy{ID: s.newID("tls_policy_override"), Active: 1}
+			applyTLSPolicy(p, attr)
+			s.tlsPolicies[p.ID] = p
+
+			return success("tls_policy_map_entry_saved", dest)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			p, ok := s.tlsPolicies[id]
+			if !ok {
+				return danger("access_denied")
+			}
+			if v, ok := attr["policy"]; ok && !tlsPolicies[str(v)] {
+				return danger("invalid_tls_policy", str(v))
+			}
+
+			applyTLSPolicy(p, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if _, ok := s.tlsPolicies[id]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.tlsPolicies, id)
+
+			return nil
+		},
+	}
+}
+
+func applyTLSPolicy(p *TLSPolicy, attr map[string]interface{}) {
+	if v, ok := attr["dest"]; ok {
+		p.Dest = str(v)
+	}
+	if v, ok := attr["policy"]; ok {
+		p.Policy = str(v)
+	}
+	if v, ok := attr["parameters"]; ok {
+		p.Parameters = str(v)
+	}
+	if v, ok := attr["active"]; ok {
+		p.Active = num(v)
+	}
+}
@@ -0,0 +1,125 @@
+package mailcowtest
+
+import "strconv"
+
+type Transport struct {
+	ID          int64  `json:"id"`
+	Destination string `json:"destination"`
+	Nexthop     string `json:"nexthop"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	IsMXBased   int64  `json:"is_mx_based"`
+	Active      int64  `json:"active"`
+}
+
+// Transport returns a copy of the stored transport, or nil when it does not
+// exist.
+func (s *Server) Transport(id int64) *Transport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.transports[id]; ok {
+		copied := *t
+		return &copied
+	}
+
+	return nil
+}
+
+// RemoveTransport deletes a transport behind the provider's back.
+func (s *Server) RemoveTransport(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.transports, id)
+}
+
+func (s *Server) transportHandler() handler {
+	return handler{
+		get: func(item string) interface{} {
+			if item == "all" {
+				var ids []int64
+				for id := range s.transports {
+					ids = append(ids, id)
+				}
+				if len(ids) == 0 {
+					// mailcow answers an empty list with {}
+					return nil
+				}
+
+				transports := []Transport{}
+				for _, id := range sortedIDs(ids) {
+					transports = append(transports, *s.transports[id])
+				}
+				return transports
+			}
+
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if t, ok := s.transports[id]; ok {
+				return t
+			}
+
+			return nil
+		},
+		add: func(attr map[string]interface{}) response {
+			destination, nexthop := str(attr["destination"]), str(attr["nexthop"])
+			if destination == "" || nexthop == "" {
+				return *danger("invalid_destination", destination)
+			}
+			for _, t := range s.transports {
+				if t.Destination == destination {
+					return *danger("transport_dest_exists", destination)
+				}
+			}
+
+			// mailcow does not return the ID of the new transport
+			t := &Transport{ID: s.newID("transport"), Active: 1}
+			applyTransport(t, attr)
+			s.transports[t.ID] = t
+
+			return success("transport_added", destination)
+		},
+		edit: func(item string, attr map[string]interface{}) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			t, ok := s.transports[id]
+			if !ok {
+				return danger("access_denied")
+			}
+
+			applyTransport(t, attr)
+
+			return nil
+		},
+		delete: func(item string) *response {
+			id, _ := strconv.ParseInt(item, 10, 64)
+			if _, ok := s.transports[id]; !ok {
+				return danger("access_denied")
+			}
+
+			delete(s.transports, id)
+
+			return nil
+		},
+	}
+}
+
+func applyTransport(t *Transport, attr map[string]interface{}) {
+	if v, ok := attr["destination"]; ok {
+		t.Destination = str(v)
+	}
+	if v, ok := attr["nexthop"]; ok {
+		t.Nexthop = str(v)
+	}
+	if v, ok := attr["username"]; ok {
+		t.Username = str(v)
+	}
+	if v, ok := attr["password"]; ok {
+		t.Password = str(v)
+	}
+	if v, ok := attr["is_mx_based"]; ok {
+		t.IsMXBased = num(v)
+	}
+	if v, ok := attr["active"]; ok {
+		t.Active = num(v)
+	}
+}
@@ -20,6 +20,10 @@ func DefaultInt64(v int64) DefaultValue {
 	return DefaultValue{Value: types.Int64{Value: v}}
 }
 
+func DefaultString(v string) DefaultValue {
+	return DefaultValue{Value: types.String{Value: v}}
+}
+
 func (d DefaultValue) Description(ctx context.Context) string {
 	return ""
 }
@@ -0,0 +1,48 @@
+package plan_modifiers
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// UnknownWhenChanged marks a computed attribute as unknown when the planned
+// value of another top-level attribute differs from its state, so a value kept
+// by UseStateForUnknown can still change in the apply.
+type UnknownWhenChanged struct {
+	Attribute string
+}
+
+func (u UnknownWhenChanged) Description(ctx context.Context) string {
+	return fmt.Sprintf("The value is recomputed when %s changes.", u.Attribute)
+}
+
+func (u UnknownWhenChanged) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("The value is recomputed when `%s` changes.", u.Attribute)
+}
+
+func (u UnknownWhenChanged) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, res *tfsdk.ModifyAttributePlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || res.AttributePlan == nil {
+		return
+	}
+
+	path := tftypes.NewAttributePath().WithAttributeName(u.Attribute)
+
+	var planned, current attr.Value
+	res.Diagnostics.Append(req.Plan.GetAttribute(ctx, path, &planned)...)
+	res.Diagnostics.Append(req.State.GetAttribute(ctx, path, &current)...)
+	if res.Diagnostics.HasError() || planned.Equal(current) {
+		return
+	}
+
+	typ := res.AttributePlan.Type(ctx)
+	unknown, err := typ.ValueFromTerraform(ctx, tftypes.NewValue(typ.TerraformType(ctx), tftypes.UnknownValue))
+	if err != nil {
+		res.Diagnostics.AddAttributeError(req.AttributePath, "Plan Modification Error", err.Error())
+		return
+	}
+
+	res.AttributePlan = unknown
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type aliasDomainsDataSourceType struct{}
+
+func (t aliasDomainsDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"alias_domains": {
+				Computed: true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"alias_domain": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"target_domain": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"active": {
+						Type:     types.BoolType,
+						Computed: true,
+					},
+				}, tfsdk.ListNestedAttributesOptions{}),
+			},
+		},
+	}, nil
+}
+
+func (r aliasDomainsDataSourceType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return aliasDomainsDataSource{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type aliasDomainsDataSourceData struct {
+	AliasDomains []aliasDomainItem `tfsdk:"alias_domains"`
+	ID           types.String      `tfsdk:"id"`
+}
+
+type aliasDomainItem struct {
+	Active       types.Bool   `tfsdk:"active"`
+	AliasDomain  types.String `tfsdk:"alias_domain"`
+	TargetDomain types.String `tfsdk:"target_domain"`
+}
+
+type aliasDomainsDataSource struct {
+	p provider
+}
+
+func (d aliasDomainsDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data aliasDomainsDataSourceData
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	aliasDomains, err := d.p.client.GetAllAliasDomains(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error - Get All Alias Domains", fmt.Sprintf("Unable to read, got error: %s", err))
+		return
+	}
+
+	for _, aliasDomain := range *aliasDomains {
+		a := aliasDomainItem{
+			Active:       types.Bool{Value: aliasDomain.Active == 1},
+			AliasDomain:  types.String{Value: aliasDomain.AliasDomain},
+			TargetDomain: types.String{Value: aliasDomain.TargetDomain},
+		}
+
+		data.AliasDomains = append(data.AliasDomains, a)
+	}
+
+	data.ID = types.String{Value: "all"}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
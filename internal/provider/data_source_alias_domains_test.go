@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAliasDomains(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mailcow_alias_domains" "test" {}
+`,
+				Check: resource.TestCheckResourceAttr("data.mailcow_alias_domains.test", "alias_domains.#", "0"),
+			},
+			{
+				Config: testAccResourceAliasDomainConfig(server, true) + `
+data "mailcow_alias_domains" "test" {
+  depends_on = [mailcow_alias_domain.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mailcow_alias_domains.test", "alias_domains.#", "1"),
+					resource.TestCheckResourceAttr("data.mailcow_alias_domains.test", "alias_domains.0.alias_domain", "brand.tld"),
+					resource.TestCheckResourceAttr("data.mailcow_alias_domains.test", "alias_domains.0.target_domain", "mailcow.tld"),
+					resource.TestCheckResourceAttr("data.mailcow_alias_domains.test", "alias_domains.0.active", "true"),
+				),
+			},
+		},
+	})
+}
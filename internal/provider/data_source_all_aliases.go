@@ -14,6 +14,10 @@ type allAliasesDataSourceType struct{}
 func (t allAliasesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
 		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
 			"aliases": {
 				Computed: true,
 				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
@@ -49,6 +53,7 @@ func (r allAliasesDataSourceType) NewDataSource(ctx context.Context, p tfsdk.Pro
 
 type allAliasesDataSourceData struct {
 	Aliases []allAliasItem `tfsdk:"aliases"`
+	ID      types.String   `tfsdk:"id"`
 }
 
 type allAliasItem struct {
@@ -67,7 +72,7 @@ func (d allAliasesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSource
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
-	aliases, err := d.p.client.GetAllAliases()
+	aliases, err := d.p.client.GetAllAliases(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error - Get All Aliases", fmt.Sprintf("Unable to read, got error: %s", err))
 		return
@@ -89,6 +94,8 @@ func (d allAliasesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSource
 		data.Aliases = append(data.Aliases, m)
 	}
 
+	data.ID = types.String{Value: "all"}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAllAliases(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mailcow_all_aliases" "test" {}
+`,
+				Check: resource.TestCheckResourceAttr("data.mailcow_all_aliases.test", "aliases.#", "0"),
+			},
+			{
+				Config: testAccResourceAliasConfig(server, []string{"one@example.com", "two@example.com"}) + `
+data "mailcow_all_aliases" "test" {
+  depends_on = [mailcow_alias.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mailcow_all_aliases.test", "aliases.#", "1"),
+					resource.TestCheckResourceAttr("data.mailcow_all_aliases.test", "aliases.0.alias", "info@mailcow.tld"),
+					resource.TestCheckResourceAttr("data.mailcow_all_aliases.test", "aliases.0.goto_addresses.#", "2"),
+					resource.TestCheckResourceAttr("data.mailcow_all_aliases.test", "aliases.0.goto_addresses.1", "two@example.com"),
+				),
+			},
+		},
+	})
+}
@@ -13,6 +13,10 @@ type allDomainsDataSourceType struct{}
 func (t allDomainsDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
 		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
 			"domains": {
 				Computed: true,
 				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
@@ -42,6 +46,7 @@ func (r allDomainsDataSourceType) NewDataSource(ctx context.Context, p tfsdk.Pro
 
 type alldomainDataSourceData struct {
 	Domains []alldomainItem `tfsdk:"domains"`
+	ID      types.String    `tfsdk:"id"`
 }
 
 type alldomainItem struct {
@@ -59,7 +64,7 @@ func (d alldomainDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceR
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
-	domains, err := d.p.client.GetAllDomains()
+	domains, err := d.p.client.GetAllDomains(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error - Get All Domains", fmt.Sprintf("Unable to read, got error: %s", err))
 		return
@@ -75,6 +80,8 @@ func (d alldomainDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceR
 		data.Domains = append(data.Domains, d)
 	}
 
+	data.ID = types.String{Value: "all"}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
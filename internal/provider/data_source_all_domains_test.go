@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAllDomains(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mailcow_all_domains" "test" {}
+`,
+				Check: resource.TestCheckResourceAttr("data.mailcow_all_domains.test", "domains.#", "0"),
+			},
+			{
+				Config: testAccResourceDomainConfig(server, "Example") + `
+data "mailcow_all_domains" "test" {
+  depends_on = [mailcow_domain.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mailcow_all_domains.test", "domains.#", "1"),
+					resource.TestCheckResourceAttr("data.mailcow_all_domains.test", "domains.0.domain", "mailcow.tld"),
+					resource.TestCheckResourceAttr("data.mailcow_all_domains.test", "domains.0.description", "Example"),
+				),
+			},
+		},
+	})
+}
@@ -13,6 +13,10 @@ type allMailboxesDataSourceType struct{}
 func (t allMailboxesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
 		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
 			"mailboxes": {
 				Computed: true,
 				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
@@ -50,6 +54,7 @@ func (r allMailboxesDataSourceType) NewDataSource(ctx context.Context, p tfsdk.P
 
 type allmailboxDataSourceData struct {
 	Mailboxes []allmailboxItem `tfsdk:"mailboxes"`
+	ID        types.String     `tfsdk:"id"`
 }
 
 type allmailboxItem struct {
@@ -69,7 +74,7 @@ func (d allmailboxDataSource) Read(ctx context.Context, req tfsdk.ReadDataSource
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
-	mailboxes, err := d.p.client.GetAllMailboxes()
+	mailboxes, err := d.p.client.GetAllMailboxes(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error - Get All Mailboxes", fmt.Sprintf("Unable to read, got error: %s", err))
 		return
@@ -87,6 +92,8 @@ func (d allmailboxDataSource) Read(ctx context.Context, req tfsdk.ReadDataSource
 		data.Mailboxes = append(data.Mailboxes, m)
 	}
 
+	data.ID = types.String{Value: "all"}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAllMailboxes(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mailcow_all_mailboxes" "test" {}
+`,
+				Check: resource.TestCheckResourceAttr("data.mailcow_all_mailboxes.test", "mailboxes.#", "0"),
+			},
+			{
+				Config: testAccResourceMailboxConfig(server, "User", "password") + `
+data "mailcow_all_mailboxes" "test" {
+  depends_on = [mailcow_mailbox.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mailcow_all_mailboxes.test", "mailboxes.#", "1"),
+					resource.TestCheckResourceAttr("data.mailcow_all_mailboxes.test", "mailboxes.0.email", "user@mailcow.tld"),
+					resource.TestCheckResourceAttr("data.mailcow_all_mailboxes.test", "mailboxes.0.name", "User"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type allResourcesDataSourceType struct{}
+
+func (t allResourcesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"resources": {
+				Computed: true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"name": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"description": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"domain": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"kind": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"multiple_bookings": {
+						Type:     types.Int64Type,
+						Computed: true,
+					},
+					"active": {
+						Type:     types.BoolType,
+						Computed: true,
+					},
+				}, tfsdk.ListNestedAttributesOptions{}),
+			},
+		},
+	}, nil
+}
+
+func (r allResourcesDataSourceType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return allResourcesDataSource{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type allResourcesDataSourceData struct {
+	Resources []allResourceItem `tfsdk:"resources"`
+	ID        types.String      `tfsdk:"id"`
+}
+
+type allResourceItem struct {
+	Active           types.Bool   `tfsdk:"active"`
+	Description      types.String `tfsdk:"description"`
+	Domain           types.String `tfsdk:"domain"`
+	Kind             types.String `tfsdk:"kind"`
+	MultipleBookings types.Int64  `tfsdk:"multiple_bookings"`
+	Name             types.String `tfsdk:"name"`
+}
+
+type allResourcesDataSource struct {
+	p provider
+}
+
+func (d allResourcesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data allResourcesDataSourceData
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	resources, err := d.p.client.GetAllResources(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error - Get All Resources", fmt.Sprintf("Unable to read, got error: %s", err))
+		return
+	}
+
+	for _, resource := range *resources {
+		r := allResourceItem{
+			Active:           types.Bool{Value: resource.Active == 1},
+			Description:      types.String{Value: resource.Description},
+			Domain:           types.String{Value: resource.Domain},
+			Kind:             types.String{Value: resource.Kind},
+			MultipleBookings: types.Int64{Value: resource.MultipleBookings},
+			Name:             types.String{Value: resource.Name},
+		}
+
+		data.Resources = append(data.Resources, r)
+	}
+
+	data.ID = types.String{Value: "all"}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
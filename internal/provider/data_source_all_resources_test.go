@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAllResources(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceResourceConfig(server, "Projector", "thing", 2) + `
+data "mailcow_all_resources" "test" {
+  depends_on = [mailcow_resource.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mailcow_all_resources.test", "resources.#", "1"),
+					resource.TestCheckResourceAttr("data.mailcow_all_resources.test", "resources.0.name", "Projector@mailcow.tld"),
+					resource.TestCheckResourceAttr("data.mailcow_all_resources.test", "resources.0.kind", "thing"),
+					resource.TestCheckResourceAttr("data.mailcow_all_resources.test", "resources.0.multiple_bookings", "2"),
+				),
+			},
+		},
+	})
+}
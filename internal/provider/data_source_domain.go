@@ -13,6 +13,10 @@ type domainDataSourceType struct{}
 func (t domainDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
 		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
 			"domain": {
 				Type:                types.StringType,
 				Description:         "The @domain.tld part of the email address",
@@ -41,6 +45,7 @@ type domainDataSourceData struct {
 	Active      types.Bool   `tfsdk:"active"`
 	Description types.String `tfsdk:"description"`
 	Domain      types.String `tfsdk:"domain"`
+	ID          types.String `tfsdk:"id"`
 }
 
 type domainDataSource struct {
@@ -52,7 +57,7 @@ func (d domainDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequ
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
-	domain, err := d.p.client.GetDomain(data.Domain.Value)
+	domain, err := d.p.client.GetDomain(ctx, data.Domain.Value)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error - Get Domain", fmt.Sprintf("Unable to read, got error: %s", err))
 		return
@@ -61,6 +66,7 @@ func (d domainDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequ
 	data.Active = types.Bool{Value: domain.Active == 1}
 	data.Description = types.String{Value: domain.Description}
 	data.Domain = types.String{Value: domain.Name}
+	data.ID = types.String{Value: domain.Name}
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDomain(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDomainConfig(server, "Example") + `
+data "mailcow_domain" "test" {
+  domain = mailcow_domain.test.domain
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mailcow_domain.test", "domain", "mailcow.tld"),
+					resource.TestCheckResourceAttr("data.mailcow_domain.test", "description", "Example"),
+					resource.TestCheckResourceAttr("data.mailcow_domain.test", "active", "true"),
+				),
+			},
+		},
+	})
+}
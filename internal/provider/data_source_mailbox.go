@@ -13,6 +13,10 @@ type mailboxDataSourceType struct{}
 func (t mailboxDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
 		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
 			"email": {
 				Type:     types.StringType,
 				Required: true,
@@ -49,6 +53,7 @@ type mailboxDataSourceData struct {
 	Email    types.String `tfsdk:"email"`
 	Name     types.String `tfsdk:"name"`
 	Username types.String `tfsdk:"username"`
+	ID       types.String `tfsdk:"id"`
 }
 
 type mailboxDataSource struct {
@@ -60,7 +65,7 @@ func (d mailboxDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceReq
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
-	mailbox, err := d.p.client.GetMailbox(data.Email.Value)
+	mailbox, err := d.p.client.GetMailbox(ctx, data.Email.Value)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error - Get Mailbox", fmt.Sprintf("Unable to read, got error: %s", err))
 		return
@@ -70,6 +75,7 @@ func (d mailboxDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceReq
 	data.Domain = types.String{Value: mailbox.Domain}
 	data.Name = types.String{Value: mailbox.Name}
 	data.Username = types.String{Value: mailbox.Username}
+	data.ID = types.String{Value: mailbox.Email}
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
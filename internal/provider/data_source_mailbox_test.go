@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMailbox(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceMailboxConfig(server, "User", "password") + `
+data "mailcow_mailbox" "test" {
+  email = mailcow_mailbox.test.email
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mailcow_mailbox.test", "username", "user"),
+					resource.TestCheckResourceAttr("data.mailcow_mailbox.test", "domain", "mailcow.tld"),
+					resource.TestCheckResourceAttr("data.mailcow_mailbox.test", "name", "User"),
+					resource.TestCheckResourceAttr("data.mailcow_mailbox.test", "active", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type spamPoliciesDataSourceType struct{}
+
+func (t spamPoliciesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	policy := tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+		"id": {
+			Type:     types.Int64Type,
+			Computed: true,
+		},
+		"from": {
+			Type:     types.StringType,
+			Computed: true,
+		},
+	}, tfsdk.ListNestedAttributesOptions{})
+
+	return tfsdk.Schema{
+		Description: "The whitelist and blacklist entries that apply to every mailbox of a domain.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"domain": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"whitelist": {
+				Computed:   true,
+				Attributes: policy,
+			},
+			"blacklist": {
+				Computed:   true,
+				Attributes: policy,
+			},
+		},
+	}, nil
+}
+
+func (r spamPoliciesDataSourceType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return spamPoliciesDataSource{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type spamPoliciesDataSourceData struct {
+	Blacklist []spamPolicyItem `tfsdk:"blacklist"`
+	Domain    types.String     `tfsdk:"domain"`
+	ID        types.String     `tfsdk:"id"`
+	Whitelist []spamPolicyItem `tfsdk:"whitelist"`
+}
+
+type spamPolicyItem struct {
+	From types.String `tfsdk:"from"`
+	ID   types.Int64  `tfsdk:"id"`
+}
+
+type spamPoliciesDataSource struct {
+	p provider
+}
+
+func (d spamPoliciesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data spamPoliciesDataSourceData
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	for _, list := range []struct {
+		name   string
+		target *[]spamPolicyItem
+	}{
+		{"wl", &data.Whitelist},
+		{"bl", &data.Blacklist},
+	} {
+		policies, err := d.p.client.GetDomainPolicies(ctx, data.Domain.Value, list.name)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error - Get Domain Policies", fmt.Sprintf("Unable to read, got error: %s", err))
+			return
+		}
+
+		*list.target = []spamPolicyItem{}
+		for _, policy := range *policies {
+			*list.target = append(*list.target, spamPolicyItem{
+				From: types.String{Value: policy.From},
+				ID:   types.Int64{Value: policy.ID},
+			})
+		}
+	}
+
+	data.ID = data.Domain
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
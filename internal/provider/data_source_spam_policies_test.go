@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSpamPolicies(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSpamPolicyConfig(server, "*@spam.example", "*@example.org") + `
+data "mailcow_spam_policies" "test" {
+  domain     = mailcow_domain.test.domain
+  depends_on = [mailcow_spam_policy.domain, mailcow_spam_policy.mailbox]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mailcow_spam_policies.test", "id", "mailcow.tld"),
+					resource.TestCheckResourceAttr("data.mailcow_spam_policies.test", "whitelist.#", "0"),
+					resource.TestCheckResourceAttr("data.mailcow_spam_policies.test", "blacklist.#", "1"),
+					resource.TestCheckResourceAttr("data.mailcow_spam_policies.test", "blacklist.0.from", "*@spam.example"),
+					resource.TestCheckResourceAttr("data.mailcow_spam_policies.test", "blacklist.0.id", "1"),
+				),
+			},
+		},
+	})
+}
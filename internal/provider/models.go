@@ -7,6 +7,7 @@ type Alias struct {
 	Alias         types.String   `tfsdk:"alias"`
 	GotoAddresses []types.String `tfsdk:"goto_addresses"`
 	ID            types.Int64    `tfsdk:"id"`
+	Timeouts      *Timeouts      `tfsdk:"timeouts"`
 }
 
 type Domain struct {
@@ -14,9 +15,205 @@ type Domain struct {
 	Aliases              types.Int64  `tfsdk:"aliases"`
 	Description          types.String `tfsdk:"description"`
 	Domain               types.String `tfsdk:"domain"`
+	ID                   types.String `tfsdk:"id"`
 	MailboxDefaultSizeMB types.Int64  `tfsdk:"mailbox_default_size"`
 	MailboxMaxSizeMB     types.Int64  `tfsdk:"mailbox_max_size"`
 	Mailboxes            types.Int64  `tfsdk:"mailboxes"`
-	Password             types.String `tfsdk:"password"`
 	QuotaMB              types.Int64  `tfsdk:"quota"`
+	RelayhostID          types.Int64  `tfsdk:"relayhost_id"`
+	Timeouts             *Timeouts    `tfsdk:"timeouts"`
+}
+
+type Mailbox struct {
+	Active              types.Bool   `tfsdk:"active"`
+	Domain              types.String `tfsdk:"domain"`
+	Email               types.String `tfsdk:"email"`
+	ForcePasswordUpdate types.Bool   `tfsdk:"force_password_update"`
+	ID                  types.String `tfsdk:"id"`
+	LocalPart           types.String `tfsdk:"local_part"`
+	Name                types.String `tfsdk:"name"`
+	Password            types.String `tfsdk:"password"`
+	QuotaMB             types.Int64  `tfsdk:"quota"`
+	Timeouts            *Timeouts    `tfsdk:"timeouts"`
+}
+
+type AliasDomain struct {
+	Active       types.Bool   `tfsdk:"active"`
+	AliasDomain  types.String `tfsdk:"alias_domain"`
+	ID           types.String `tfsdk:"id"`
+	TargetDomain types.String `tfsdk:"target_domain"`
+	Timeouts     *Timeouts    `tfsdk:"timeouts"`
+}
+
+type DKIMKey struct {
+	DNSTXTRecord  types.String `tfsdk:"dns_txt_record"`
+	Domain        types.String `tfsdk:"domain"`
+	DuplicateFrom types.String `tfsdk:"duplicate_from"`
+	ID            types.String `tfsdk:"id"`
+	KeySize       types.Int64  `tfsdk:"key_size"`
+	PublicKey     types.String `tfsdk:"public_key"`
+	Selector      types.String `tfsdk:"selector"`
+	Timeouts      *Timeouts    `tfsdk:"timeouts"`
+}
+
+type DomainAdmin struct {
+	ACL      types.Set    `tfsdk:"acl"`
+	Active   types.Bool   `tfsdk:"active"`
+	Domains  types.Set    `tfsdk:"domains"`
+	ID       types.String `tfsdk:"id"`
+	Password types.String `tfsdk:"password"`
+	Timeouts *Timeouts    `tfsdk:"timeouts"`
+	Username types.String `tfsdk:"username"`
+}
+
+type Relayhost struct {
+	Active   types.Bool   `tfsdk:"active"`
+	Hostname types.String `tfsdk:"hostname"`
+	ID       types.Int64  `tfsdk:"id"`
+	Password types.String `tfsdk:"password"`
+	Timeouts *Timeouts    `tfsdk:"timeouts"`
+	Username types.String `tfsdk:"username"`
+}
+
+type Transport struct {
+	Active      types.Bool   `tfsdk:"active"`
+	Destination types.String `tfsdk:"destination"`
+	ID          types.Int64  `tfsdk:"id"`
+	IsMXBased   types.Bool   `tfsdk:"is_mx_based"`
+	Nexthop     types.String `tfsdk:"nexthop"`
+	Password    types.String `tfsdk:"password"`
+	Timeouts    *Timeouts    `tfsdk:"timeouts"`
+	Username    types.String `tfsdk:"username"`
+}
+
+type SyncJob struct {
+	Active       types.Bool   `tfsdk:"active"`
+	CustomParams types.String `tfsdk:"custom_params"`
+	DeleteSource types.Bool   `tfsdk:"delete_from_source"`
+	Encryption   types.String `tfsdk:"encryption"`
+	Exclude      types.String `tfsdk:"exclude"`
+	ExitStatus   types.String `tfsdk:"exit_status"`
+	Host         types.String `tfsdk:"host"`
+	ID           types.Int64  `tfsdk:"id"`
+	Interval     types.Int64  `tfsdk:"interval"`
+	IsRunning    types.Bool   `tfsdk:"is_running"`
+	LastRun      types.String `tfsdk:"last_run"`
+	LastSuccess  types.Bool   `tfsdk:"last_run_success"`
+	Mailbox      types.String `tfsdk:"mailbox"`
+	Password     types.String `tfsdk:"password"`
+	Port         types.Int64  `tfsdk:"port"`
+	Subfolder    types.String `tfsdk:"subfolder"`
+	Timeouts     *Timeouts    `tfsdk:"timeouts"`
+	User         types.String `tfsdk:"user"`
+}
+
+type SpamPolicy struct {
+	Domain   types.String `tfsdk:"domain"`
+	From     types.String `tfsdk:"from"`
+	ID       types.Int64  `tfsdk:"id"`
+	Mailbox  types.String `tfsdk:"mailbox"`
+	Timeouts *Timeouts    `tfsdk:"timeouts"`
+	Type     types.String `tfsdk:"type"`
+}
+
+type AppPassword struct {
+	Active    types.Bool   `tfsdk:"active"`
+	ID        types.Int64  `tfsdk:"id"`
+	Mailbox   types.String `tfsdk:"mailbox"`
+	Name      types.String `tfsdk:"name"`
+	Password  types.String `tfsdk:"password"`
+	Protocols types.Set    `tfsdk:"protocols"`
+	Timeouts  *Timeouts    `tfsdk:"timeouts"`
+}
+
+type BCCMap struct {
+	Active    types.Bool   `tfsdk:"active"`
+	BCCDest   types.String `tfsdk:"bcc_dest"`
+	ID        types.Int64  `tfsdk:"id"`
+	LocalDest types.String `tfsdk:"local_dest"`
+	Timeouts  *Timeouts    `tfsdk:"timeouts"`
+	Type      types.String `tfsdk:"type"`
+}
+
+type RecipientMap struct {
+	Active          types.Bool   `tfsdk:"active"`
+	ID              types.Int64  `tfsdk:"id"`
+	RecipientMapNew types.String `tfsdk:"recipient_map_new"`
+	RecipientMapOld types.String `tfsdk:"recipient_map_old"`
+	Timeouts        *Timeouts    `tfsdk:"timeouts"`
+}
+
+type Resource struct {
+	Active           types.Bool   `tfsdk:"active"`
+	Description      types.String `tfsdk:"description"`
+	Domain           types.String `tfsdk:"domain"`
+	ID               types.String `tfsdk:"id"`
+	Kind             types.String `tfsdk:"kind"`
+	MultipleBookings types.Int64  `tfsdk:"multiple_bookings"`
+	Name             types.String `tfsdk:"name"`
+	Timeouts         *Timeouts    `tfsdk:"timeouts"`
+}
+
+type MailboxFilter struct {
+	Active      types.Bool   `tfsdk:"active"`
+	Description types.String `tfsdk:"description"`
+	FilterType  types.String `tfsdk:"filter_type"`
+	ID          types.Int64  `tfsdk:"id"`
+	ScriptData  types.String `tfsdk:"script_data"`
+	Timeouts    *Timeouts    `tfsdk:"timeouts"`
+	Username    types.String `tfsdk:"username"`
+}
+
+type TLSPolicy struct {
+	Active      types.Bool   `tfsdk:"active"`
+	Destination types.String `tfsdk:"destination"`
+	ID          types.Int64  `tfsdk:"id"`
+	Parameters  types.String `tfsdk:"parameters"`
+	Policy      types.String `tfsdk:"policy"`
+	Timeouts    *Timeouts    `tfsdk:"timeouts"`
+}
+
+type DomainRatelimit struct {
+	Domain   types.String `tfsdk:"domain"`
+	Frame    types.String `tfsdk:"frame"`
+	ID       types.String `tfsdk:"id"`
+	Timeouts *Timeouts    `tfsdk:"timeouts"`
+	Value    types.Int64  `tfsdk:"value"`
+}
+
+type MailboxRatelimit struct {
+	Frame    types.String `tfsdk:"frame"`
+	ID       types.String `tfsdk:"id"`
+	Mailbox  types.String `tfsdk:"mailbox"`
+	Timeouts *Timeouts    `tfsdk:"timeouts"`
+	Value    types.Int64  `tfsdk:"value"`
+}
+
+type ForwardingHost struct {
+	FilterSpam  types.Bool   `tfsdk:"filter_spam"`
+	Hostname    types.String `tfsdk:"hostname"`
+	ID          types.String `tfsdk:"id"`
+	IPAddresses types.Set    `tfsdk:"ip_addresses"`
+	Timeouts    *Timeouts    `tfsdk:"timeouts"`
+}
+
+type OAuth2Client struct {
+	ClientID          types.String `tfsdk:"client_id"`
+	ClientSecret      types.String `tfsdk:"client_secret"`
+	ID                types.Int64  `tfsdk:"id"`
+	RedirectURI       types.String `tfsdk:"redirect_uri"`
+	RegenerateTrigger types.String `tfsdk:"regenerate_trigger"`
+	Timeouts          *Timeouts    `tfsdk:"timeouts"`
+}
+
+type Fail2banConfig struct {
+	BanTime     types.Int64  `tfsdk:"ban_time"`
+	Blacklist   types.Set    `tfsdk:"blacklist"`
+	ID          types.String `tfsdk:"id"`
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	NetbanIPv4  types.Int64  `tfsdk:"netban_ipv4"`
+	NetbanIPv6  types.Int64  `tfsdk:"netban_ipv6"`
+	RetryWindow types.Int64  `tfsdk:"retry_window"`
+	Timeouts    *Timeouts    `tfsdk:"timeouts"`
+	Whitelist   types.Set    `tfsdk:"whitelist"`
 }
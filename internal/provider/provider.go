@@ -2,11 +2,14 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	client "github.com/kraihn/terraform-provider-mailcow/internal/client"
 	"os"
+	"strconv"
+	"time"
 )
 
 func New() tfsdk.Provider {
@@ -38,13 +41,110 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 				Computed:            true,
 				Sensitive:           true,
 			},
+			"max_retries": {
+				Type:                types.Int64Type,
+				Description:         "How many times a failed request is retried on connection errors, HTTP 429 and 5xx responses. Defaults to 3.",
+				MarkdownDescription: "How many times a failed request is retried on connection errors, HTTP 429 and 5xx responses. Defaults to `3`.",
+				Optional:            true,
+			},
+			"retry_wait_min": {
+				Type:                types.Int64Type,
+				Description:         "Minimum number of seconds to wait before retrying a request. Defaults to 1.",
+				MarkdownDescription: "Minimum number of seconds to wait before retrying a request. Defaults to `1`.",
+				Optional:            true,
+			},
+			"retry_wait_max": {
+				Type:                types.Int64Type,
+				Description:         "Maximum number of seconds to wait before retrying a request. Defaults to 30.",
+				MarkdownDescription: "Maximum number of seconds to wait before retrying a request. Defaults to `30`.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": {
+				Type:                types.Int64Type,
+				Description:         "Maximum number of API requests in flight at once, shared by all resources and data sources. Defaults to 0, no limit.",
+				MarkdownDescription: "Maximum number of API requests in flight at once, shared by all resources and data sources. Defaults to `0`, no limit.",
+				Optional:            true,
+			},
+			"requests_per_second": {
+				Type:                types.Float64Type,
+				Description:         "Maximum number of API requests sent per second, shared by all resources and data sources. Defaults to 0, no limit.",
+				MarkdownDescription: "Maximum number of API requests sent per second, shared by all resources and data sources. Defaults to `0`, no limit.",
+				Optional:            true,
+			},
+			"ca_cert_pem": {
+				Type:                types.StringType,
+				Description:         "PEM encoded certificate authority trusted in addition to the system pool. Conflicts with ca_cert_file. Can be sourced from MAILCOW_CA_CERT_PEM.",
+				MarkdownDescription: "PEM encoded certificate authority trusted in addition to the system pool. Conflicts with `ca_cert_file`. Can be sourced from `MAILCOW_CA_CERT_PEM`.",
+				Optional:            true,
+			},
+			"ca_cert_file": {
+				Type:                types.StringType,
+				Description:         "Path to a PEM encoded certificate authority trusted in addition to the system pool. Conflicts with ca_cert_pem. Can be sourced from MAILCOW_CA_CERT_FILE.",
+				MarkdownDescription: "Path to a PEM encoded certificate authority trusted in addition to the system pool. Conflicts with `ca_cert_pem`. Can be sourced from `MAILCOW_CA_CERT_FILE`.",
+				Optional:            true,
+			},
+			"client_cert": {
+				Type:                types.StringType,
+				Description:         "PEM encoded client certificate for mutual TLS. Can be sourced from MAILCOW_CLIENT_CERT.",
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Can be sourced from `MAILCOW_CLIENT_CERT`.",
+				Optional:            true,
+			},
+			"client_key": {
+				Type:                types.StringType,
+				Description:         "PEM encoded private key of the client certificate. Can be sourced from MAILCOW_CLIENT_KEY.",
+				MarkdownDescription: "PEM encoded private key of the client certificate. Can be sourced from `MAILCOW_CLIENT_KEY`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": {
+				Type:                types.BoolType,
+				Description:         "Skip verification of the server certificate. Can be sourced from MAILCOW_INSECURE_SKIP_VERIFY.",
+				MarkdownDescription: "Skip verification of the server certificate. Can be sourced from `MAILCOW_INSECURE_SKIP_VERIFY`.",
+				Optional:            true,
+			},
+			"proxy_url": {
+				Type:                types.StringType,
+				Description:         "URL of the proxy used to reach the Mailcow server, otherwise HTTPS_PROXY and HTTP_PROXY are honoured. Can be sourced from MAILCOW_PROXY_URL.",
+				MarkdownDescription: "URL of the proxy used to reach the Mailcow server, otherwise `HTTPS_PROXY` and `HTTP_PROXY` are honoured. Can be sourced from `MAILCOW_PROXY_URL`.",
+				Optional:            true,
+			},
+			"timeout": {
+				Type:                types.Int64Type,
+				Description:         "Number of seconds before a single request times out, 0 for no timeout. Can be sourced from MAILCOW_TIMEOUT.",
+				MarkdownDescription: "Number of seconds before a single request times out, `0` for no timeout. Can be sourced from `MAILCOW_TIMEOUT`.",
+				Optional:            true,
+			},
 		},
 	}, nil
 }
 
 type providerData struct {
-	Host   types.String `tfsdk:"host"`
-	ApiKey types.String `tfsdk:"apikey"`
+	Host         types.String `tfsdk:"host"`
+	ApiKey       types.String `tfsdk:"apikey"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.Int64  `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.Int64  `tfsdk:"retry_wait_max"`
+
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	Timeout            types.Int64  `tfsdk:"timeout"`
+}
+
+// stringOrEnv returns the configured value, falling back to the environment
+// variable env when it is not set.
+func stringOrEnv(value types.String, env string) string {
+	if value.Null {
+		return os.Getenv(env)
+	}
+
+	return value.Value
 }
 
 func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderRequest, resp *tfsdk.ConfigureProviderResponse) {
@@ -99,7 +199,102 @@ func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderReq
 		return
 	}
 
-	c, _ := client.NewClient(&config.Host.Value, &config.ApiKey.Value)
+	c, _ := client.NewClient(&host, &apiKey)
+
+	transport := client.TransportConfig{
+		CACertPEM:     stringOrEnv(config.CACertPEM, "MAILCOW_CA_CERT_PEM"),
+		CACertFile:    stringOrEnv(config.CACertFile, "MAILCOW_CA_CERT_FILE"),
+		ClientCertPEM: stringOrEnv(config.ClientCert, "MAILCOW_CLIENT_CERT"),
+		ClientKeyPEM:  stringOrEnv(config.ClientKey, "MAILCOW_CLIENT_KEY"),
+		ProxyURL:      stringOrEnv(config.ProxyURL, "MAILCOW_PROXY_URL"),
+	}
+
+	if config.InsecureSkipVerify.Null {
+		if env := os.Getenv("MAILCOW_INSECURE_SKIP_VERIFY"); env != "" {
+			insecure, err := strconv.ParseBool(env)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid MAILCOW_INSECURE_SKIP_VERIFY",
+					fmt.Sprintf("Unable to parse %q as a boolean", env),
+				)
+				return
+			}
+			transport.InsecureSkipVerify = insecure
+		}
+	} else {
+		transport.InsecureSkipVerify = config.InsecureSkipVerify.Value
+	}
+
+	if config.Timeout.Null {
+		if env := os.Getenv("MAILCOW_TIMEOUT"); env != "" {
+			seconds, err := strconv.ParseInt(env, 10, 64)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid MAILCOW_TIMEOUT",
+					fmt.Sprintf("Unable to parse %q as a number of seconds", env),
+				)
+				return
+			}
+			transport.Timeout = time.Duration(seconds) * time.Second
+		}
+	} else {
+		transport.Timeout = time.Duration(config.Timeout.Value) * time.Second
+	}
+
+	if transport.Timeout < 0 {
+		resp.Diagnostics.AddError(
+			"Invalid timeout",
+			"timeout cannot be negative",
+		)
+		return
+	}
+
+	httpClient, err := client.NewHTTPClient(transport)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create client",
+			fmt.Sprintf("Invalid transport configuration: %s", err),
+		)
+		return
+	}
+	c.HttpClient = httpClient
+
+	if !config.MaxRetries.Null {
+		if config.MaxRetries.Value < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid max_retries",
+				"max_retries cannot be negative",
+			)
+			return
+		}
+		c.MaxRetries = int(config.MaxRetries.Value)
+	}
+
+	if !config.RetryWaitMin.Null {
+		c.RetryWaitMin = time.Duration(config.RetryWaitMin.Value) * time.Second
+	}
+
+	if !config.RetryWaitMax.Null {
+		c.RetryWaitMax = time.Duration(config.RetryWaitMax.Value) * time.Second
+	}
+
+	if c.RetryWaitMin < 0 || c.RetryWaitMax < c.RetryWaitMin {
+		resp.Diagnostics.AddError(
+			"Invalid retry wait",
+			"retry_wait_min cannot be negative or greater than retry_wait_max",
+		)
+		return
+	}
+
+	if config.MaxConcurrentRequests.Value < 0 || config.RequestsPerSecond.Value < 0 {
+		resp.Diagnostics.AddError(
+			"Invalid rate limit",
+			"max_concurrent_requests and requests_per_second cannot be negative",
+		)
+		return
+	}
+
+	c.SetRateLimit(int(config.MaxConcurrentRequests.Value), config.RequestsPerSecond.Value)
 
 	p.client = *c
 	p.configured = true
@@ -107,17 +302,39 @@ func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderReq
 
 func (p *provider) GetResources(ctx context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
 	return map[string]tfsdk.ResourceType{
-		"mailcow_alias":   resourceAliasType{},
-		"mailcow_domain":  resourceDomainType{},
+		"mailcow_alias":             resourceAliasType{},
+		"mailcow_alias_domain":      resourceAliasDomainType{},
+		"mailcow_app_password":      resourceAppPasswordType{},
+		"mailcow_bcc_map":           resourceBCCMapType{},
+		"mailcow_dkim_key":          resourceDKIMKeyType{},
+		"mailcow_domain":            resourceDomainType{},
+		"mailcow_domain_admin":      resourceDomainAdminType{},
+		"mailcow_domain_ratelimit":  resourceDomainRatelimitType{},
+		"mailcow_fail2ban_config":   resourceFail2banConfigType{},
+		"mailcow_forwarding_host":   resourceForwardingHostType{},
+		"mailcow_mailbox":           resourceMailboxType{},
+		"mailcow_mailbox_filter":    resourceMailboxFilterType{},
+		"mailcow_mailbox_ratelimit": resourceMailboxRatelimitType{},
+		"mailcow_oauth2_client":     resourceOAuth2ClientType{},
+		"mailcow_recipient_map":     resourceRecipientMapType{},
+		"mailcow_relayhost":         resourceRelayhostType{},
+		"mailcow_resource":          resourceResourceType{},
+		"mailcow_spam_policy":       resourceSpamPolicyType{},
+		"mailcow_syncjob":           resourceSyncJobType{},
+		"mailcow_tls_policy":        resourceTLSPolicyType{},
+		"mailcow_transport":         resourceTransportType{},
 	}, nil
 }
 
 func (p *provider) GetDataSources(ctx context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
 	return map[string]tfsdk.DataSourceType{
+		"mailcow_alias_domains": aliasDomainsDataSourceType{},
 		"mailcow_all_aliases":   allAliasesDataSourceType{},
 		"mailcow_all_domains":   allDomainsDataSourceType{},
 		"mailcow_all_mailboxes": allMailboxesDataSourceType{},
+		"mailcow_all_resources": allResourcesDataSourceType{},
 		"mailcow_domain":        domainDataSourceType{},
 		"mailcow_mailbox":       mailboxDataSourceType{},
+		"mailcow_spam_policies": spamPoliciesDataSourceType{},
 	}, nil
 }
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+const testAccAPIKey = "mailcowtest-api-key"
+
+// testAccProtoV6ProviderFactories are used to instantiate the provider during
+// acceptance testing. The factory function is called for every Terraform CLI
+// command executed to create a provider server to which the CLI can reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"mailcow": func() (tfprotov6.ProviderServer, error) {
+		return tfsdk.NewProtocol6Server(New()), nil
+	},
+}
+
+// testAccServer starts a fake mailcow API that is closed when the test ends.
+func testAccServer(t *testing.T) *mailcowtest.Server {
+	t.Helper()
+
+	server := mailcowtest.NewServer(testAccAPIKey)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// testAccProviderConfig points the provider at the fake mailcow API.
+func testAccProviderConfig(server *mailcowtest.Server) string {
+	return fmt.Sprintf(`
+provider "mailcow" {%s}
+`, testAccProviderBody(server))
+}
+
+func TestProvider(t *testing.T) {
+	p := New()
+
+	_, diags := p.GetSchema(context.Background())
+	if diags.HasError() {
+		t.Fatalf("provider schema: %v", diags)
+	}
+}
+
+func TestAccProviderConfigure(t *testing.T) {
+	server := testAccServer(t)
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		// config is the body of the provider block
+		config string
+		// err matches the expected error, empty for a valid configuration
+		err string
+	}{
+		{
+			name: "host and apikey from the environment",
+			env: map[string]string{
+				"MAILCOW_HOST":   server.URL,
+				"MAILCOW_APIKEY": testAccAPIKey,
+			},
+			config: `max_retries = 0`,
+		},
+		{
+			name:   "missing host",
+			env:    map[string]string{"MAILCOW_APIKEY": testAccAPIKey},
+			config: `max_retries = 0`,
+			err:    `Unable to find host`,
+		},
+		{
+			name:   "missing apikey",
+			env:    map[string]string{"MAILCOW_HOST": server.URL},
+			config: `max_retries = 0`,
+			err:    `Unable to find apiKey`,
+		},
+		{
+			name: "config takes precedence over the environment",
+			env: map[string]string{
+				"MAILCOW_HOST":                 "http://127.0.0.1:1",
+				"MAILCOW_APIKEY":               "wrong-key",
+				"MAILCOW_TIMEOUT":              "soon",
+				"MAILCOW_INSECURE_SKIP_VERIFY": "maybe",
+				"MAILCOW_CA_CERT_PEM":          "not a certificate",
+			},
+			config: fmt.Sprintf(`
+host                 = %q
+apikey               = %q
+max_retries          = 0
+timeout              = 10
+insecure_skip_verify = false
+ca_cert_pem          = ""
+`, server.URL, testAccAPIKey),
+		},
+		{
+			name:   "invalid MAILCOW_TIMEOUT",
+			env:    map[string]string{"MAILCOW_TIMEOUT": "soon"},
+			config: testAccProviderBody(server),
+			err:    `Invalid MAILCOW_TIMEOUT`,
+		},
+		{
+			name:   "invalid MAILCOW_INSECURE_SKIP_VERIFY",
+			env:    map[string]string{"MAILCOW_INSECURE_SKIP_VERIFY": "maybe"},
+			config: testAccProviderBody(server),
+			err:    `Invalid MAILCOW_INSECURE_SKIP_VERIFY`,
+		},
+		{
+			name:   "invalid MAILCOW_CA_CERT_PEM",
+			env:    map[string]string{"MAILCOW_CA_CERT_PEM": "not a certificate"},
+			config: testAccProviderBody(server),
+			err:    `no valid certificate found`,
+		},
+		{
+			name:   "MAILCOW_PROXY_URL",
+			env:    map[string]string{"MAILCOW_PROXY_URL": "http://127.0.0.1:1"},
+			config: testAccProviderBody(server),
+			err:    `Unable to read`,
+		},
+		{
+			name:   "client_cert without client_key",
+			config: testAccProviderBody(server) + `client_cert = "cert"`,
+			err:    `Unable to create client`,
+		},
+		{
+			name:   "negative timeout",
+			config: testAccProviderBody(server) + `timeout = -1`,
+			err:    `Invalid timeout`,
+		},
+		{
+			name: "negative max_retries",
+			config: fmt.Sprintf(`
+host        = %q
+apikey      = %q
+max_retries = -1
+`, server.URL, testAccAPIKey),
+			err: `Invalid max_retries`,
+		},
+		{
+			name:   "retry_wait_min above retry_wait_max",
+			config: testAccProviderBody(server) + "retry_wait_min = 10\nretry_wait_max = 5",
+			err:    `Invalid retry wait`,
+		},
+		{
+			name:   "negative retry_wait_min",
+			config: testAccProviderBody(server) + `retry_wait_min = -1`,
+			err:    `Invalid retry wait`,
+		},
+		{
+			name:   "negative max_concurrent_requests",
+			config: testAccProviderBody(server) + `max_concurrent_requests = -1`,
+			err:    `Invalid rate limit`,
+		},
+		{
+			name:   "negative requests_per_second",
+			config: testAccProviderBody(server) + `requests_per_second = -0.5`,
+			err:    `Invalid rate limit`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, env := range []string{
+				"MAILCOW_HOST", "MAILCOW_APIKEY", "MAILCOW_CA_CERT_PEM", "MAILCOW_CA_CERT_FILE",
+				"MAILCOW_CLIENT_CERT", "MAILCOW_CLIENT_KEY", "MAILCOW_INSECURE_SKIP_VERIFY",
+				"MAILCOW_PROXY_URL", "MAILCOW_TIMEOUT",
+			} {
+				t.Setenv(env, test.env[env])
+			}
+
+			step := resource.TestStep{
+				Config: fmt.Sprintf(`
+provider "mailcow" {
+%s
+}
+
+data "mailcow_all_domains" "test" {}
+`, test.config),
+			}
+			if test.err != "" {
+				step.ExpectError = regexp.MustCompile(test.err)
+			}
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+// testAccProviderBody is the body of testAccProviderConfig, for tests adding
+// their own provider arguments.
+func testAccProviderBody(server *mailcowtest.Server) string {
+	return fmt.Sprintf(`
+  host        = %q
+  apikey      = %q
+  max_retries = 0
+`, server.URL, testAccAPIKey)
+}
@@ -1,18 +1,15 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
 	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
 	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
-	"log"
-	"net/http"
 	"strconv"
 	"strings"
 )
@@ -50,6 +47,7 @@ func (r resourceAliasType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diag
 					plan_modifiers.DefaultBool(true),
 				},
 			},
+			"timeouts": timeoutsAttribute(),
 		},
 	}, nil
 }
@@ -80,43 +78,38 @@ func (r resourceAlias) Create(ctx context.Context, req tfsdk.CreateResourceReque
 		return
 	}
 
-	url := r.p.client.HostURL + "/api/v1/add/alias"
-
-	active := "0"
-	if plan.Active.Value {
-		active = "1"
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	defer cancel()
 
 	var destinations []string
 	for _, destination := range plan.GotoAddresses {
 		destinations = append(destinations, destination.Value)
 	}
 
-	data := []byte(`{
-		"active": "` + active + `",
-		"address": "` + plan.Alias.Value + `",
-		"goto": "` + strings.Join(destinations, ",") + `"
-	}`)
-
-	request, _ := http.NewRequest("POST", url, bytes.NewBuffer(data))
-	value, err := r.p.client.DoRequest(request)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read, got error: %s", err))
+	id, err := r.p.client.AddAlias(ctx, client.AliasRequest{
+		Address: plan.Alias.Value,
+		GoTo:    strings.Join(destinations, ","),
+		Active:  boolToString(plan.Active.Value),
+	})
+	if client.IsAlreadyExists(err) {
+		resp.Diagnostics.AddError("Alias Already Exists", fmt.Sprintf("The alias already exists in mailcow, import it with its alias ID instead: %s", err))
 		return
 	}
-
-	type test struct {
-		Message []string `json:"msg"`
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create alias, got error: %s", err))
+		return
 	}
-	var outcome []test
-	json.Unmarshal(value, &outcome)
 
-	id, _ := strconv.ParseInt(outcome[0].Message[2], 10, 64)
 	result := Alias{
 		ID:            types.Int64{Value: id},
 		Alias:         plan.Alias,
 		GotoAddresses: plan.GotoAddresses,
 		Active:        plan.Active,
+		Timeouts:      plan.Timeouts,
 	}
 
 	diags = resp.State.Set(ctx, result)
@@ -134,13 +127,16 @@ func (r resourceAlias) Read(ctx context.Context, req tfsdk.ReadResourceRequest,
 		return
 	}
 
-	alias, err := r.p.client.GetAlias(state.ID.Value)
+	alias, err := r.p.client.GetAlias(ctx, state.ID.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read alias, got error: %s", err))
 		return
 	}
 
-	log.Printf("arg %s", state.ID.Value)
 	var destinations []types.String
 	for _, destination := range strings.Split(alias.GoTo, ",") {
 		destinations = append(destinations, types.String{Value: destination})
@@ -167,34 +163,25 @@ func (r resourceAlias) Update(ctx context.Context, req tfsdk.UpdateResourceReque
 		return
 	}
 
-	url := r.p.client.HostURL + "/api/v1/edit/alias"
-
-	id := strconv.FormatInt(plan.ID.Value, 10)
-	active := "0"
-	if plan.Active.Value {
-		active = "1"
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	defer cancel()
 
 	var destinations []string
 	for _, destination := range plan.GotoAddresses {
 		destinations = append(destinations, destination.Value)
 	}
 
-	data := []byte(`{
-		"attr": {
-			"active": "` + active + `",
-			"address": "` + plan.Alias.Value + `",
-			"goto": "` + strings.Join(destinations, ",") + `"
-		},
-		"items": [
-			"` + id + `"
-		]
-	}`)
-
-	request, _ := http.NewRequest("POST", url, bytes.NewBuffer(data))
-	_, err := r.p.client.DoRequest(request)
+	err := r.p.client.EditAlias(ctx, plan.ID.Value, client.AliasRequest{
+		Address: plan.Alias.Value,
+		GoTo:    strings.Join(destinations, ","),
+		Active:  boolToString(plan.Active.Value),
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update alias, got error: %s", err))
 		return
 	}
 
@@ -215,9 +202,16 @@ func (r resourceAlias) Delete(ctx context.Context, req tfsdk.DeleteResourceReque
 		return
 	}
 
-	err := r.p.client.DeleteAlias(state.ID.Value)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read, got error: %s", err))
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteAlias(ctx, state.ID.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete alias, got error: %s", err))
 		return
 	}
 
@@ -225,5 +219,18 @@ func (r resourceAlias) Delete(ctx context.Context, req tfsdk.DeleteResourceReque
 }
 
 func (r resourceAlias) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	importInt64ID(ctx, "alias", req, resp)
+}
+
+// importInt64ID imports an object with a numeric mailcow ID into the id
+// attribute, leaving Read to fill in the rest.
+func importInt64ID(ctx context.Context, kind string, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected a numeric %s ID, got: %s", kind, req.ID))
+		return
+	}
+
+	diags := resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), id)
+	resp.Diagnostics.Append(diags...)
 }
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
+)
+
+type resourceAliasDomainType struct{}
+
+func (r resourceAliasDomainType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"alias_domain": {
+				Type:        types.StringType,
+				Description: "The domain that mirrors target_domain.",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"target_domain": {
+				Type:        types.StringType,
+				Description: "The existing mailcow domain that receives mail for alias_domain.",
+				Required:    true,
+			},
+			"active": {
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultBool(true),
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceAliasDomainType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceAliasDomain{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceAliasDomain struct {
+	p provider
+}
+
+func (r resourceAliasDomain) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan AliasDomain
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.AddAliasDomain(ctx, client.AliasDomainRequest{
+		AliasDomain:  plan.AliasDomain.Value,
+		TargetDomain: plan.TargetDomain.Value,
+		Active:       boolToString(plan.Active.Value),
+	})
+	if client.IsAlreadyExists(err) {
+		resp.Diagnostics.AddError("Alias Domain Already Exists", fmt.Sprintf("The alias domain already exists in mailcow, import it with its domain name instead: %s", err))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create alias domain, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = plan.AliasDomain
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceAliasDomain) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state AliasDomain
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	aliasDomain, err := r.p.client.GetAliasDomain(ctx, state.AliasDomain.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read alias domain, got error: %s", err))
+		return
+	}
+
+	state.ID = types.String{Value: aliasDomain.AliasDomain}
+	state.AliasDomain = types.String{Value: aliasDomain.AliasDomain}
+	state.TargetDomain = types.String{Value: aliasDomain.TargetDomain}
+	state.Active = types.Bool{Value: aliasDomain.Active == 1}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceAliasDomain) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan AliasDomain
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.EditAliasDomain(ctx, plan.AliasDomain.Value, client.AliasDomainRequest{
+		TargetDomain: plan.TargetDomain.Value,
+		Active:       boolToString(plan.Active.Value),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update alias domain, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = plan.AliasDomain
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceAliasDomain) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state AliasDomain
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteAliasDomain(ctx, state.AliasDomain.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete alias domain, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r resourceAliasDomain) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("alias_domain"), req, resp)
+}
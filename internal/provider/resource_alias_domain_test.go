@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceAliasDomain(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAliasDomainDestroy(server, "brand.tld"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAliasDomainConfig(server, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_alias_domain.test", "id", "brand.tld"),
+					resource.TestCheckResourceAttr("mailcow_alias_domain.test", "alias_domain", "brand.tld"),
+					resource.TestCheckResourceAttr("mailcow_alias_domain.test", "target_domain", "mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_alias_domain.test", "active", "true"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_alias_domain.test",
+				ImportState:             true,
+				ImportStateId:           "brand.tld",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				Config: testAccResourceAliasDomainConfig(server, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_alias_domain.test", "active", "false"),
+					testAccCheckAliasDomainActive(server, "brand.tld", 0),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveAliasDomain("brand.tld") },
+				Config:    testAccResourceAliasDomainConfig(server, false),
+				Check:     testAccCheckAliasDomainActive(server, "brand.tld", 0),
+			},
+		},
+	})
+}
+
+func testAccResourceAliasDomainConfig(server *mailcowtest.Server, active bool) string {
+	return testAccResourceDomainConfig(server, "Example") + fmt.Sprintf(`
+resource "mailcow_alias_domain" "test" {
+  alias_domain  = "brand.tld"
+  target_domain = mailcow_domain.test.domain
+  active        = %t
+}
+`, active)
+}
+
+func testAccCheckAliasDomainActive(server *mailcowtest.Server, name string, active int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		aliasDomain := server.AliasDomain(name)
+		if aliasDomain == nil {
+			return fmt.Errorf("alias domain %s does not exist", name)
+		}
+		if aliasDomain.Active != active {
+			return fmt.Errorf("alias domain %s has active = %d, want %d", name, aliasDomain.Active, active)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAliasDomainDestroy(server *mailcowtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if server.AliasDomain(name) != nil {
+			return fmt.Errorf("alias domain %s still exists", name)
+		}
+
+		return nil
+	}
+}
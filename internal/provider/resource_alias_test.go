@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceAlias(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAliasConfig(server, []string{"one@example.com", "two@example.com"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_alias.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_alias.test", "alias", "info@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_alias.test", "goto_addresses.#", "2"),
+					resource.TestCheckResourceAttr("mailcow_alias.test", "active", "true"),
+				),
+			},
+			{
+				ResourceName:      "mailcow_alias.test",
+				ImportState:       true,
+				ImportStateId:     "1",
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"timeouts",
+				},
+			},
+			{
+				Config: testAccResourceAliasConfig(server, []string{"three@example.com"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_alias.test", "goto_addresses.#", "1"),
+					resource.TestCheckResourceAttr("mailcow_alias.test", "goto_addresses.0", "three@example.com"),
+					testAccCheckAliasGoTo(server, 1, "three@example.com"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveAlias(1) },
+				Config:    testAccResourceAliasConfig(server, []string{"three@example.com"}),
+				Check:     resource.TestCheckResourceAttr("mailcow_alias.test", "id", "2"),
+			},
+		},
+	})
+}
+
+func testAccResourceAliasConfig(server *mailcowtest.Server, destinations []string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mailcow_domain" "test" {
+  domain               = "mailcow.tld"
+  description          = "Alias test"
+  quota                = 10240
+  mailboxes            = 10
+  mailbox_default_size = 1024
+  mailbox_max_size     = 2048
+  aliases              = 100
+}
+
+resource "mailcow_alias" "test" {
+  alias          = "info@${mailcow_domain.test.domain}"
+  goto_addresses = ["%s"]
+}
+`, strings.Join(destinations, `", "`))
+}
+
+func testAccCheckAliasGoTo(server *mailcowtest.Server, id int64, goTo string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		alias := server.Alias(id)
+		if alias == nil {
+			return fmt.Errorf("alias %d does not exist", id)
+		}
+
+		if alias.GoTo != goTo {
+			return fmt.Errorf("alias %d goes to %q, expected %q", id, alias.GoTo, goTo)
+		}
+
+		return nil
+	}
+}
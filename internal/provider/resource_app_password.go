@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
+	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	generatedPasswordLength   = 32
+	generatedPasswordAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+type resourceAppPasswordType struct{}
+
+func (r resourceAppPasswordType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "An app password of a mailbox, limited to the given protocols.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.Int64Type,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"mailbox": {
+				Type:        types.StringType,
+				Description: "The email address of the mailbox.",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"name": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"password": {
+				Type:        types.StringType,
+				Description: fmt.Sprintf("The app password. When unset, a random password of %d characters is generated.", generatedPasswordLength),
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"protocols": {
+				Type: types.SetType{
+					ElemType: types.StringType,
+				},
+				Description: "The protocols the password can be used for: imap, smtp, dav, eas and pop3.",
+				Required:    true,
+				Validators: []tfsdk.AttributeValidator{
+					validators.StringOneOfValidator{Values: []string{"imap", "smtp", "dav", "eas", "pop3"}},
+				},
+			},
+			"active": {
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultBool(true),
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceAppPasswordType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceAppPassword{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceAppPassword struct {
+	p provider
+}
+
+func (r resourceAppPassword) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan AppPassword
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	if plan.Password.Unknown {
+		password, err := generatePassword(generatedPasswordLength)
+		if err != nil {
+			resp.Diagnostics.AddError("Password Generation Error", fmt.Sprintf("Unable to generate a password, got error: %s", err))
+			return
+		}
+		plan.Password = types.String{Value: password}
+	}
+
+	appPassword := client.AppPasswordRequest{
+		Username:        plan.Mailbox.Value,
+		Name:            plan.Name.Value,
+		Password:        plan.Password.Value,
+		PasswordConfirm: plan.Password.Value,
+		Active:          boolToString(plan.Active.Value),
+	}
+	resp.Diagnostics.Append(plan.protocols(ctx, &appPassword)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := r.p.client.AddAppPassword(ctx, appPassword)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create app password, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = types.Int64{Value: id}
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceAppPassword) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state AppPassword
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appPassword, err := r.p.client.GetAppPassword(ctx, state.Mailbox.Value, state.ID.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read app password, got error: %s", err))
+		return
+	}
+
+	state.Name = types.String{Value: appPassword.Name}
+	state.Active = types.Bool{Value: appPassword.Active == 1}
+
+	state.Protocols = types.Set{ElemType: types.StringType, Elems: []attr.Value{}}
+	for _, protocol := range []struct {
+		name   string
+		access int64
+	}{
+		{"dav", appPassword.DAVAccess},
+		{"eas", appPassword.EASAccess},
+		{"imap", appPassword.IMAPAccess},
+		{"pop3", appPassword.POP3Access},
+		{"smtp", appPassword.SMTPAccess},
+	} {
+		if protocol.access == 1 {
+			state.Protocols.Elems = append(state.Protocols.Elems, types.String{Value: protocol.name})
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceAppPassword) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan AppPassword
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state AppPassword
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	appPassword := client.AppPasswordRequest{
+		Name:   plan.Name.Value,
+		Active: boolToString(plan.Active.Value),
+	}
+	if !plan.Password.Equal(state.Password) {
+		appPassword.Password = plan.Password.Value
+		appPassword.PasswordConfirm = plan.Password.Value
+	}
+	resp.Diagnostics.Append(plan.protocols(ctx, &appPassword)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.p.client.EditAppPassword(ctx, state.ID.Value, appPassword)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update app password, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = state.ID
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceAppPassword) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state AppPassword
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteAppPassword(ctx, state.ID.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete app password, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts <mailbox>/<ID>, as mailcow lists app passwords per mailbox.
+func (r resourceAppPassword) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected <mailbox>/<ID>, got: %s", req.ID))
+		return
+	}
+
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected a numeric app password ID, got: %s", parts[1]))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("mailbox"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), id)...)
+}
+
+// protocols sets the protocols of appPassword from a, which mailcow names
+// like "imap_access".
+func (a AppPassword) protocols(ctx context.Context, appPassword *client.AppPasswordRequest) diag.Diagnostics {
+	var protocols []string
+	diags := a.Protocols.ElementsAs(ctx, &protocols, false)
+
+	appPassword.Protocols = []string{}
+	for _, protocol := range protocols {
+		appPassword.Protocols = append(appPassword.Protocols, protocol+"_access")
+	}
+
+	return diags
+}
+
+// generatePassword returns a random alphanumeric password of length characters.
+func generatePassword(length int) (string, error) {
+	var password strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(generatedPasswordAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		password.WriteByte(generatedPasswordAlphabet[n.Int64()])
+	}
+
+	return password.String(), nil
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceAppPassword(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAppPasswordDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceAppPasswordConfig(server, `["imap", "sieve"]`, ""),
+				ExpectError: regexp.MustCompile(`got: "sieve"`),
+			},
+			{
+				// Without a password, one is generated
+				Config: testAccResourceAppPasswordConfig(server, `["imap", "smtp"]`, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_app_password.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_app_password.test", "mailbox", "user@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_app_password.test", "name", "Printer"),
+					resource.TestCheckResourceAttr("mailcow_app_password.test", "active", "true"),
+					resource.TestCheckResourceAttr("mailcow_app_password.test", "protocols.#", "2"),
+					resource.TestMatchResourceAttr("mailcow_app_password.test", "password", regexp.MustCompile(`^[A-Za-z0-9]{32}$`)),
+					testAccCheckAppPasswordMatchesState(server, "mailcow_app_password.test"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_app_password.test",
+				ImportState:             true,
+				ImportStateId:           "user@mailcow.tld/1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "timeouts"},
+			},
+			{
+				Config: testAccResourceAppPasswordConfig(server, `["dav", "eas", "pop3"]`, `password = "app-secret"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_app_password.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_app_password.test", "password", "app-secret"),
+					resource.TestCheckTypeSetElemAttr("mailcow_app_password.test", "protocols.*", "pop3"),
+					testAccCheckAppPasswordMatchesState(server, "mailcow_app_password.test"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveAppPassword(1) },
+				Config:    testAccResourceAppPasswordConfig(server, `["dav", "eas", "pop3"]`, `password = "app-secret"`),
+				Check:     resource.TestCheckResourceAttr("mailcow_app_password.test", "id", "2"),
+			},
+		},
+	})
+}
+
+func testAccResourceAppPasswordConfig(server *mailcowtest.Server, protocols, password string) string {
+	return testAccResourceMailboxConfig(server, "User", "secret") + fmt.Sprintf(`
+resource "mailcow_app_password" "test" {
+  mailbox   = mailcow_mailbox.test.email
+  name      = "Printer"
+  protocols = %s
+  %s
+}
+`, protocols, password)
+}
+
+func testAccCheckAppPasswordMatchesState(server *mailcowtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in state", name)
+		}
+
+		id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		appPassword := server.AppPassword(id)
+		if appPassword == nil {
+			return fmt.Errorf("app password %d does not exist", id)
+		}
+		if appPassword.Password != rs.Primary.Attributes["password"] {
+			return fmt.Errorf("app password %d differs from the password in state", id)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAppPasswordDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_app_password" {
+				continue
+			}
+
+			id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			if server.AppPassword(id) != nil {
+				return fmt.Errorf("app password %d still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
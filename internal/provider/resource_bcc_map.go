@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
+	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
+)
+
+type resourceBCCMapType struct{}
+
+func (r resourceBCCMapType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "A BCC map sending a blind copy of mail from or to a local address to another address.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.Int64Type,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"local_dest": {
+				Type:        types.StringType,
+				Description: "The local domain or address whose mail is copied.",
+				Required:    true,
+			},
+			"bcc_dest": {
+				Type:        types.StringType,
+				Description: "The address receiving the copies.",
+				Required:    true,
+			},
+			"type": {
+				Type:        types.StringType,
+				Description: "Whether mail sent by (sender) or to (recipient) local_dest is copied.",
+				Required:    true,
+				Validators: []tfsdk.AttributeValidator{
+					validators.StringOneOfValidator{Values: []string{"sender", "recipient"}},
+				},
+			},
+			"active": {
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultBool(true),
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceBCCMapType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceBCCMap{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceBCCMap struct {
+	p provider
+}
+
+func (b BCCMap) request() client.BCCMapRequest {
+	return client.BCCMapRequest{
+		LocalDest: b.LocalDest.Value,
+		BCCDest:   b.BCCDest.Value,
+		Type:      b.Type.Value,
+		Active:    boolToString(b.Active.Value),
+	}
+}
+
+func (r resourceBCCMap) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan BCCMap
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	id, err := r.p.client.AddBCCMap(ctx, plan.request())
+	if client.IsAlreadyExists(err) {
+		resp.Diagnostics.AddError("BCC Map Already Exists", fmt.Sprintf("A BCC map of this type already exists for the local destination in mailcow, import it with its ID instead: %s", err))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create BCC map, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = types.Int64{Value: id}
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceBCCMap) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state BCCMap
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bccMap, err := r.p.client.GetBCCMap(ctx, state.ID.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read BCC map, got error: %s", err))
+		return
+	}
+
+	state.LocalDest = types.String{Value: bccMap.LocalDest}
+	state.BCCDest = types.String{Value: bccMap.BCCDest}
+	state.Type = types.String{Value: bccMap.Type}
+	state.Active = types.Bool{Value: bccMap.Active == 1}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceBCCMap) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan BCCMap
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state BCCMap
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.EditBCCMap(ctx, state.ID.Value, plan.request())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update BCC map, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = state.ID
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceBCCMap) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state BCCMap
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteBCCMap(ctx, state.ID.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete BCC map, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r resourceBCCMap) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	importInt64ID(ctx, "BCC map", req, resp)
+}
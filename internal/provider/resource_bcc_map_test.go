@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceBCCMap(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBCCMapDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceBCCMapConfig(server, "archive@example.com", "both"),
+				ExpectError: regexp.MustCompile(`got: "both"`),
+			},
+			{
+				Config: testAccResourceBCCMapConfig(server, "archive@example.com", "sender"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_bcc_map.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_bcc_map.test", "local_dest", "mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_bcc_map.test", "bcc_dest", "archive@example.com"),
+					resource.TestCheckResourceAttr("mailcow_bcc_map.test", "type", "sender"),
+					resource.TestCheckResourceAttr("mailcow_bcc_map.test", "active", "true"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_bcc_map.test",
+				ImportState:             true,
+				ImportStateId:           "1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				Config: testAccResourceBCCMapConfig(server, "audit@example.com", "recipient"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_bcc_map.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_bcc_map.test", "type", "recipient"),
+					testAccCheckBCCMapDest(server, 1, "audit@example.com"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveBCCMap(1) },
+				Config:    testAccResourceBCCMapConfig(server, "audit@example.com", "recipient"),
+				Check:     testAccCheckBCCMapDest(server, 2, "audit@example.com"),
+			},
+		},
+	})
+}
+
+func testAccResourceBCCMapConfig(server *mailcowtest.Server, bccDest string, kind string) string {
+	return testAccResourceDomainConfig(server, "BCC") + fmt.Sprintf(`
+resource "mailcow_bcc_map" "test" {
+  local_dest = mailcow_domain.test.domain
+  bcc_dest   = %q
+  type       = %q
+}
+`, bccDest, kind)
+}
+
+func testAccCheckBCCMapDest(server *mailcowtest.Server, id int64, bccDest string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		bccMap := server.BCCMap(id)
+		if bccMap == nil {
+			return fmt.Errorf("BCC map %d does not exist", id)
+		}
+		if bccMap.BCCDest != bccDest {
+			return fmt.Errorf("BCC map %d copies to %s, want %s", id, bccMap.BCCDest, bccDest)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckBCCMapDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_bcc_map" {
+				continue
+			}
+
+			id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			if server.BCCMap(id) != nil {
+				return fmt.Errorf("BCC map %d still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
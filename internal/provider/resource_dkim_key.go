@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
+	"strconv"
+)
+
+const (
+	defaultDKIMSelector = "dkim"
+	defaultDKIMKeySize  = 2048
+)
+
+type resourceDKIMKeyType struct{}
+
+func (r resourceDKIMKeyType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "A DKIM key of a domain. All arguments force a new key, as mailcow cannot change an existing one.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"domain": {
+				Type:        types.StringType,
+				Description: "The domain or alias domain the key signs for.",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"selector": {
+				Type:        types.StringType,
+				Description: fmt.Sprintf("The DKIM selector. Defaults to %q, or the selector of duplicate_from.", defaultDKIMSelector),
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"key_size": {
+				Type:        types.Int64Type,
+				Description: fmt.Sprintf("The RSA key size in bits. Defaults to %d, or the size of duplicate_from.", defaultDKIMKeySize),
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.Int64OneOfValidator{Values: []int64{1024, 2048, 3072, 4096}},
+				},
+			},
+			"duplicate_from": {
+				Type:        types.StringType,
+				Description: "Copy the key of this domain instead of generating a new one. Conflicts with selector and key_size.",
+				Optional:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"public_key": {
+				Type:        types.StringType,
+				Description: "The base64 encoded public key.",
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"dns_txt_record": {
+				Type:        types.StringType,
+				Description: "The value of the TXT record to publish at <selector>._domainkey.<domain>.",
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceDKIMKeyType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceDKIMKey{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceDKIMKey struct {
+	p provider
+}
+
+func (r resourceDKIMKey) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var config DKIMKey
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.DuplicateFrom.Null {
+		return
+	}
+
+	conflicts := []struct {
+		name string
+		null bool
+	}{
+		{"selector", config.Selector.Null},
+		{"key_size", config.KeySize.Null},
+	}
+	for _, conflict := range conflicts {
+		if !conflict.null {
+			resp.Diagnostics.AddAttributeError(
+				tftypes.NewAttributePath().WithAttributeName(conflict.name),
+				"Conflicting Attributes",
+				fmt.Sprintf("%s cannot be set together with duplicate_from, the key is copied as is.", conflict.name),
+			)
+		}
+	}
+}
+
+func (r resourceDKIMKey) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan DKIMKey
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	var err error
+	if !plan.DuplicateFrom.Null {
+		// mailcow would silently replace a key the domain already has
+		_, err = r.p.client.GetDKIM(ctx, plan.Domain.Value)
+		if err == nil {
+			resp.Diagnostics.AddError("DKIM Key Already Exists", "The domain already has a DKIM key in mailcow, import it with its domain name instead.")
+			return
+		}
+		if !client.IsNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DKIM key, got error: %s", err))
+			return
+		}
+
+		err = r.p.client.DuplicateDKIM(ctx, client.DKIMDuplicateRequest{
+			FromDomain: plan.DuplicateFrom.Value,
+			ToDomain:   plan.Domain.Value,
+		})
+	} else {
+		selector := defaultDKIMSelector
+		if !plan.Selector.Unknown {
+			selector = plan.Selector.Value
+		}
+
+		keySize := int64(defaultDKIMKeySize)
+		if !plan.KeySize.Unknown {
+			keySize = plan.KeySize.Value
+		}
+
+		err = r.p.client.AddDKIM(ctx, client.DKIMRequest{
+			Domains:  plan.Domain.Value,
+			Selector: selector,
+			KeySize:  strconv.FormatInt(keySize, 10),
+		})
+	}
+	if client.IsAlreadyExists(err) {
+		resp.Diagnostics.AddError("DKIM Key Already Exists", fmt.Sprintf("The domain already has a DKIM key in mailcow, import it with its domain name instead: %s", err))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create DKIM key, got error: %s", err))
+		return
+	}
+
+	key, err := r.p.client.GetDKIM(ctx, plan.Domain.Value)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DKIM key, got error: %s", err))
+		return
+	}
+
+	result := plan
+	resp.Diagnostics.Append(result.update(plan.Domain.Value, key)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceDKIMKey) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state DKIMKey
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.p.client.GetDKIM(ctx, state.Domain.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DKIM key, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(state.update(state.Domain.Value, key)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only stores the new timeouts, every other attribute forces a new key.
+func (r resourceDKIMKey) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan DKIMKey
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceDKIMKey) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state DKIMKey
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteDKIM(ctx, state.Domain.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete DKIM key, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r resourceDKIMKey) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("domain"), req, resp)
+}
+
+// update sets the computed attributes of k from the key mailcow returned.
+func (k *DKIMKey) update(domain string, key *client.DKIMResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	keySize, err := key.Length.Int64()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to parse DKIM key length %q, got error: %s", key.Length, err))
+		return diags
+	}
+
+	k.ID = types.String{Value: domain}
+	k.Domain = types.String{Value: domain}
+	k.Selector = types.String{Value: key.Selector}
+	k.KeySize = types.Int64{Value: keySize}
+	k.PublicKey = types.String{Value: key.PublicKey}
+	k.DNSTXTRecord = types.String{Value: key.TXT}
+
+	return diags
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceDKIMKey(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDKIMKeyDestroy(server, "mailcow.tld"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceDKIMKeyConfig(server, "key_size = 1000"),
+				ExpectError: regexp.MustCompile(`Value must be one of 1024, 2048, 3072, 4096, got: 1000`),
+			},
+			{
+				Config: testAccResourceDKIMKeyConfig(server, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_dkim_key.test", "id", "mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_dkim_key.test", "selector", "dkim"),
+					resource.TestCheckResourceAttr("mailcow_dkim_key.test", "key_size", "2048"),
+					resource.TestMatchResourceAttr("mailcow_dkim_key.test", "public_key", regexp.MustCompile(`^[A-Za-z0-9+/]+=*$`)),
+					resource.TestMatchResourceAttr("mailcow_dkim_key.test", "dns_txt_record", regexp.MustCompile(`^v=DKIM1;k=rsa;t=s;s=email;p=[A-Za-z0-9+/]+=*$`)),
+					testAccCheckDKIMKeySelector(server, "mailcow.tld", "dkim"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_dkim_key.test",
+				ImportState:             true,
+				ImportStateId:           "mailcow.tld",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				// Changing the selector generates a new key
+				Config: testAccResourceDKIMKeyConfig(server, `selector = "mail"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_dkim_key.test", "selector", "mail"),
+					testAccCheckDKIMKeySelector(server, "mailcow.tld", "mail"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveDKIMKey("mailcow.tld") },
+				Config:    testAccResourceDKIMKeyConfig(server, `selector = "mail"`),
+				Check:     testAccCheckDKIMKeySelector(server, "mailcow.tld", "mail"),
+			},
+		},
+	})
+}
+
+func TestAccResourceDKIMKey_duplicate(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDKIMKeyDestroy(server, "brand.tld"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceDKIMKeyDuplicateConfig(server, `selector = "other"`),
+				ExpectError: regexp.MustCompile(`selector cannot be set together with duplicate_from`),
+			},
+			{
+				Config: testAccResourceDKIMKeyDuplicateSourceConfig(server),
+			},
+			{
+				// A key generated in the mailcow UI must not be replaced
+				PreConfig:   func() { server.AddDKIMKey("brand.tld", "legacy", 2048) },
+				Config:      testAccResourceDKIMKeyDuplicateConfig(server, ""),
+				ExpectError: regexp.MustCompile(`DKIM Key Already Exists`),
+			},
+			{
+				PreConfig: func() { server.RemoveDKIMKey("brand.tld") },
+				Config:    testAccResourceDKIMKeyDuplicateConfig(server, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_dkim_key.brand", "selector", "mail"),
+					resource.TestCheckResourceAttr("mailcow_dkim_key.brand", "key_size", "1024"),
+					resource.TestCheckResourceAttrPair("mailcow_dkim_key.brand", "public_key", "mailcow_dkim_key.test", "public_key"),
+					resource.TestCheckResourceAttrPair("mailcow_dkim_key.brand", "dns_txt_record", "mailcow_dkim_key.test", "dns_txt_record"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceDKIMKeyConfig(server *mailcowtest.Server, extra string) string {
+	return testAccResourceDomainConfig(server, "Example") + fmt.Sprintf(`
+resource "mailcow_dkim_key" "test" {
+  domain = mailcow_domain.test.domain
+  %s
+}
+`, extra)
+}
+
+func testAccResourceDKIMKeyDuplicateSourceConfig(server *mailcowtest.Server) string {
+	return testAccResourceAliasDomainConfig(server, true) + `
+resource "mailcow_dkim_key" "test" {
+  domain   = mailcow_domain.test.domain
+  selector = "mail"
+  key_size = 1024
+}
+`
+}
+
+func testAccResourceDKIMKeyDuplicateConfig(server *mailcowtest.Server, extra string) string {
+	return testAccResourceDKIMKeyDuplicateSourceConfig(server) + fmt.Sprintf(`
+resource "mailcow_dkim_key" "brand" {
+  domain         = mailcow_alias_domain.test.alias_domain
+  duplicate_from = mailcow_dkim_key.test.domain
+  %s
+}
+`, extra)
+}
+
+func testAccCheckDKIMKeySelector(server *mailcowtest.Server, domain, selector string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		key := server.DKIMKey(domain)
+		if key == nil {
+			return fmt.Errorf("domain %s has no DKIM key", domain)
+		}
+		if key.Selector != selector {
+			return fmt.Errorf("DKIM key of %s has selector %q, want %q", domain, key.Selector, selector)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDKIMKeyDestroy(server *mailcowtest.Server, domain string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if server.DKIMKey(domain) != nil {
+			return fmt.Errorf("domain %s still has a DKIM key", domain)
+		}
+
+		return nil
+	}
+}
@@ -1,16 +1,14 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
 	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
-	"log"
-	"net/http"
 	"strconv"
 )
 
@@ -19,6 +17,13 @@ type resourceDomainType struct{}
 func (r resourceDomainType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
 		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
 			"domain": {
 				Type:     types.StringType,
 				Required: true,
@@ -58,6 +63,16 @@ func (r resourceDomainType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Dia
 				Type:     types.Int64Type,
 				Required: true,
 			},
+			"relayhost_id": {
+				Type:        types.Int64Type,
+				Description: "The ID of the mailcow_relayhost that relays outgoing mail of the domain, 0 to send directly.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultInt64(0),
+				},
+			},
+			"timeouts": timeoutsAttribute(),
 		},
 	}, nil
 }
@@ -89,33 +104,35 @@ func (r resourceDomain) Create(ctx context.Context, req tfsdk.CreateResourceRequ
 		return
 	}
 
-	//Generate the URL to access
-	url := r.p.client.HostURL + "/api/v1/add/domain"
-
-	active := "0"
-	if plan.Active.Value {
-		active = "1"
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.AddDomain(ctx, client.DomainRequest{
+		Domain:      plan.Domain.Value,
+		Description: plan.Description.Value,
+		Active:      boolToString(plan.Active.Value),
+		Aliases:     strconv.FormatInt(plan.Aliases.Value, 10),
+		Mailboxes:   strconv.FormatInt(plan.Mailboxes.Value, 10),
+		DefQuota:    strconv.FormatInt(plan.MailboxDefaultSizeMB.Value, 10),
+		MaxQuota:    strconv.FormatInt(plan.MailboxMaxSizeMB.Value, 10),
+		Quota:       strconv.FormatInt(plan.QuotaMB.Value, 10),
+		RelayHost:   strconv.FormatInt(plan.RelayhostID.Value, 10),
+	})
+	if client.IsAlreadyExists(err) {
+		resp.Diagnostics.AddError("Domain Already Exists", fmt.Sprintf("The domain already exists in mailcow, import it with its domain name instead: %s", err))
+		return
 	}
-
-	data := []byte(`{
-		"active": "` + active + `",
-		"aliases": "` + strconv.FormatInt(plan.Aliases.Value, 10) + `",
-		"defquota": "` + strconv.FormatInt(plan.MailboxDefaultSizeMB.Value, 10) + `",
-		"description": "` + plan.Description.Value + `",
-		"domain": "` + plan.Domain.Value + `",
-		"mailboxes": "` + strconv.FormatInt(plan.Mailboxes.Value, 10) + `",
-		"maxquota": "` + strconv.FormatInt(plan.MailboxMaxSizeMB.Value, 10) + `",
-		"quota": "` + strconv.FormatInt(plan.QuotaMB.Value, 10) + `"
-	}`)
-
-	request, _ := http.NewRequest("POST", url, bytes.NewBuffer(data))
-	_, err := r.p.client.DoRequest(request)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create domain, got error: %s", err))
 		return
 	}
 
 	var result = plan
+	result.ID = plan.Domain
 
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
@@ -132,12 +149,17 @@ func (r resourceDomain) Read(ctx context.Context, req tfsdk.ReadResourceRequest,
 		return
 	}
 
-	domain, err := r.p.client.GetDomain(state.Domain.Value)
+	domain, err := r.p.client.GetDomain(ctx, state.Domain.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
 		return
 	}
 
+	state.ID = types.String{Value: domain.Name}
 	state.Domain = types.String{Value: domain.Name}
 	state.Description = types.String{Value: domain.Description}
 	state.Active = types.Bool{Value: domain.Active == 1}
@@ -147,6 +169,13 @@ func (r resourceDomain) Read(ctx context.Context, req tfsdk.ReadResourceRequest,
 	state.MailboxMaxSizeMB = types.Int64{Value: domain.MailboxMaxSizeBytes / 1024 / 1024}
 	state.Aliases = types.Int64{Value: domain.Aliases}
 
+	relayhostID, err := domain.RelayHost.Int64()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse relayhost %q, got error: %s", domain.RelayHost, err))
+		return
+	}
+	state.RelayhostID = types.Int64{Value: relayhostID}
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -163,39 +192,30 @@ func (r resourceDomain) Update(ctx context.Context, req tfsdk.UpdateResourceRequ
 		return
 	}
 
-	//Generate the URL to access
-	url := r.p.client.HostURL + "/api/v1/edit/domain"
-
-	active := "0"
-	if plan.Active.Value {
-		active = "1"
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-
-	var jsonData = []byte(`{
-		"attr": {
-			"active": "` + active + `",
-			"aliases": "` + strconv.FormatInt(plan.Aliases.Value, 10) + `",
-			"defquota": "` + strconv.FormatInt(plan.MailboxDefaultSizeMB.Value, 10) + `",
-			"description": "` + plan.Description.Value + `",
-			"mailboxes": "` + strconv.FormatInt(plan.Mailboxes.Value, 10) + `",
-			"maxquota": "` + strconv.FormatInt(plan.MailboxMaxSizeMB.Value, 10) + `",
-			"quota": "` + strconv.FormatInt(plan.QuotaMB.Value, 10) + `"
-		},
-		"items": ["` + plan.Domain.Value + `"]
-	}`)
-
-	log.Printf("out %s", string(jsonData))
-
-	request, _ := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
-
-	_, err := r.p.client.DoRequest(request)
+	defer cancel()
+
+	err := r.p.client.EditDomain(ctx, plan.Domain.Value, client.DomainRequest{
+		Description: plan.Description.Value,
+		Active:      boolToString(plan.Active.Value),
+		Aliases:     strconv.FormatInt(plan.Aliases.Value, 10),
+		Mailboxes:   strconv.FormatInt(plan.Mailboxes.Value, 10),
+		DefQuota:    strconv.FormatInt(plan.MailboxDefaultSizeMB.Value, 10),
+		MaxQuota:    strconv.FormatInt(plan.MailboxMaxSizeMB.Value, 10),
+		Quota:       strconv.FormatInt(plan.QuotaMB.Value, 10),
+		RelayHost:   strconv.FormatInt(plan.RelayhostID.Value, 10),
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update domain, got error: %s", err))
 		return
 	}
 
 	result := plan
+	result.ID = plan.Domain
 
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
@@ -212,9 +232,16 @@ func (r resourceDomain) Delete(ctx context.Context, req tfsdk.DeleteResourceRequ
 		return
 	}
 
-	err := r.p.client.DeleteDomain(state.Domain.Value)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read, got error: %s", err))
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteDomain(ctx, state.Domain.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete domain, got error: %s", err))
 		return
 	}
 
@@ -222,5 +249,5 @@ func (r resourceDomain) Delete(ctx context.Context, req tfsdk.DeleteResourceRequ
 }
 
 func (r resourceDomain) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("domain_name"), req, resp)
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("domain"), req, resp)
 }
@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
+	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
+	"sort"
+)
+
+// domainAdminACLs are the permissions mailcow can grant a domain admin.
+var domainAdminACLs = []string{
+	"alias_domains",
+	"app_passwds",
+	"bcc_maps",
+	"domain_desc",
+	"domain_relayhost",
+	"extend_sender_acl",
+	"filters",
+	"login_as",
+	"mailbox_relayhost",
+	"protocol_access",
+	"pushover",
+	"quarantine",
+	"ratelimit",
+	"smtp_ip_access",
+	"sogo_access",
+	"spam_policy",
+	"syncjobs",
+	"unlimited_quota",
+}
+
+type resourceDomainAdminType struct{}
+
+func (r resourceDomainAdminType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"username": {
+				Type:     types.StringType,
+				Required: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"password": {
+				Type:      types.StringType,
+				Required:  true,
+				Sensitive: true,
+			},
+			"domains": {
+				Type: types.SetType{
+					ElemType: types.StringType,
+				},
+				Description: "The domains the admin manages.",
+				Required:    true,
+				Validators: []tfsdk.AttributeValidator{
+					validators.ListNotEmptyValidator{},
+				},
+			},
+			"active": {
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultBool(true),
+				},
+			},
+			"acl": {
+				Type: types.SetType{
+					ElemType: types.StringType,
+				},
+				Description: "The permissions granted to the admin, such as syncjobs, quarantine, login_as or spam_policy. When set, permissions not listed are revoked. When unset, the current ACL is kept, so a new admin gets the mailcow defaults.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.StringOneOfValidator{Values: domainAdminACLs},
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceDomainAdminType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceDomainAdmin{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceDomainAdmin struct {
+	p provider
+}
+
+func (r resourceDomainAdmin) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan DomainAdmin
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	domains := []string{}
+	resp.Diagnostics.Append(plan.Domains.ElementsAs(ctx, &domains, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.p.client.AddDomainAdmin(ctx, client.DomainAdminRequest{
+		Username:        plan.Username.Value,
+		Domains:         domains,
+		Password:        plan.Password.Value,
+		PasswordConfirm: plan.Password.Value,
+		Active:          boolToString(plan.Active.Value),
+	})
+	if client.IsAlreadyExists(err) {
+		resp.Diagnostics.AddError("Domain Admin Already Exists", fmt.Sprintf("The domain admin already exists in mailcow, import it with its username instead: %s", err))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create domain admin, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = plan.Username
+
+	// Without an acl in the configuration, keep and record mailcow's defaults
+	if !plan.ACL.Unknown {
+		acl := []string{}
+		resp.Diagnostics.Append(plan.ACL.ElementsAs(ctx, &acl, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		err = r.p.client.EditDomainAdminACL(ctx, plan.Username.Value, client.DomainAdminACLRequest{ACL: acl})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set domain admin ACL, got error: %s", err))
+			return
+		}
+	} else {
+		domainAdmin, err := r.p.client.GetDomainAdmin(ctx, plan.Username.Value)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain admin, got error: %s", err))
+			return
+		}
+
+		result.ACL = grantedACLs(domainAdmin.ACL)
+	}
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceDomainAdmin) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state DomainAdmin
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainAdmin, err := r.p.client.GetDomainAdmin(ctx, state.Username.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain admin, got error: %s", err))
+		return
+	}
+
+	state.ID = types.String{Value: domainAdmin.Username}
+	state.Username = types.String{Value: domainAdmin.Username}
+	state.Active = types.Bool{Value: domainAdmin.Active == 1}
+
+	state.Domains = types.Set{ElemType: types.StringType, Elems: []attr.Value{}}
+	for _, domain := range domainAdmin.Domains {
+		state.Domains.Elems = append(state.Domains.Elems, types.String{Value: domain})
+	}
+
+	// Older mailcow versions do not return the ACL, keep the known one then
+	if domainAdmin.ACL != nil {
+		state.ACL = grantedACLs(domainAdmin.ACL)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceDomainAdmin) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan DomainAdmin
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state DomainAdmin
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	domains := []string{}
+	resp.Diagnostics.Append(plan.Domains.ElementsAs(ctx, &domains, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainAdmin := client.DomainAdminRequest{
+		Domains: domains,
+		Active:  boolToString(plan.Active.Value),
+	}
+
+	// Only send the password when it changed, otherwise mailcow rehashes it
+	if !plan.Password.Equal(state.Password) {
+		domainAdmin.Password = plan.Password.Value
+		domainAdmin.PasswordConfirm = plan.Password.Value
+	}
+
+	err := r.p.client.EditDomainAdmin(ctx, plan.Username.Value, domainAdmin)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update domain admin, got error: %s", err))
+		return
+	}
+
+	acl := []string{}
+	resp.Diagnostics.Append(plan.ACL.ElementsAs(ctx, &acl, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = r.p.client.EditDomainAdminACL(ctx, plan.Username.Value, client.DomainAdminACLRequest{ACL: acl})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set domain admin ACL, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = plan.Username
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceDomainAdmin) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state DomainAdmin
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteDomainAdmin(ctx, state.Username.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete domain admin, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r resourceDomainAdmin) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("username"), req, resp)
+}
+
+// grantedACLs returns the names of the ACLs mailcow reports as enabled.
+func grantedACLs(acl map[string]json.Number) types.Set {
+	var names []string
+	for name, value := range acl {
+		if value.String() == "1" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	granted := types.Set{ElemType: types.StringType, Elems: []attr.Value{}}
+	for _, name := range names {
+		granted.Elems = append(granted.Elems, types.String{Value: name})
+	}
+
+	return granted
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceDomainAdmin(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainAdminDestroy(server, "customer"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceDomainAdminConfig(server, "secret-1", `acl = ["syncjobs", "root"]`),
+				ExpectError: regexp.MustCompile(`got: "root"`),
+			},
+			{
+				// Without acl, the mailcow defaults are recorded
+				Config: testAccResourceDomainAdminConfig(server, "secret-1", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_domain_admin.test", "id", "customer"),
+					resource.TestCheckResourceAttr("mailcow_domain_admin.test", "domains.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mailcow_domain_admin.test", "domains.*", "mailcow.tld"),
+					resource.TestCheckTypeSetElemAttr("mailcow_domain_admin.test", "domains.*", "alpha.tld"),
+					resource.TestCheckResourceAttr("mailcow_domain_admin.test", "active", "true"),
+					resource.TestCheckTypeSetElemAttr("mailcow_domain_admin.test", "acl.*", "quarantine"),
+					resource.TestCheckTypeSetElemAttr("mailcow_domain_admin.test", "acl.*", "login_as"),
+					testAccCheckDomainAdminACL(server, "customer", "syncjobs", 0),
+				),
+			},
+			{
+				ResourceName:            "mailcow_domain_admin.test",
+				ImportState:             true,
+				ImportStateId:           "customer",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "timeouts"},
+			},
+			{
+				Config: testAccResourceDomainAdminConfig(server, "secret-2", `acl = ["syncjobs", "spam_policy"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_domain_admin.test", "acl.#", "2"),
+					testAccCheckDomainAdminACL(server, "customer", "syncjobs", 1),
+					testAccCheckDomainAdminACL(server, "customer", "spam_policy", 1),
+					testAccCheckDomainAdminACL(server, "customer", "login_as", 0),
+					testAccCheckDomainAdminPassword(server, "customer", "secret-2"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveDomainAdmin("customer") },
+				Config:    testAccResourceDomainAdminConfig(server, "secret-2", `acl = ["syncjobs", "spam_policy"]`),
+				Check:     testAccCheckDomainAdminACL(server, "customer", "syncjobs", 1),
+			},
+		},
+	})
+}
+
+func testAccResourceDomainAdminConfig(server *mailcowtest.Server, password, acl string) string {
+	return testAccResourceDomainConfig(server, "Example") + fmt.Sprintf(`
+resource "mailcow_domain_admin" "test" {
+  username = "customer"
+  password = %q
+  domains  = [mailcow_domain.test.domain, mailcow_domain.alpha.domain]
+  %s
+}
+
+# mailcow returns the domains ordered by name, unlike the config
+resource "mailcow_domain" "alpha" {
+  domain               = "alpha.tld"
+  description          = "Alpha"
+  quota                = 10240
+  mailboxes            = 10
+  mailbox_default_size = 1024
+  mailbox_max_size     = 2048
+  aliases              = 100
+}
+`, password, acl)
+}
+
+func testAccCheckDomainAdminACL(server *mailcowtest.Server, username, acl string, value int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		domainAdmin := server.DomainAdmin(username)
+		if domainAdmin == nil {
+			return fmt.Errorf("domain admin %s does not exist", username)
+		}
+		if domainAdmin.ACL[acl] != value {
+			return fmt.Errorf("domain admin %s has %s = %d, want %d", username, acl, domainAdmin.ACL[acl], value)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDomainAdminPassword(server *mailcowtest.Server, username, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		domainAdmin := server.DomainAdmin(username)
+		if domainAdmin == nil {
+			return fmt.Errorf("domain admin %s does not exist", username)
+		}
+		if domainAdmin.Password != password {
+			return fmt.Errorf("domain admin %s has an outdated password", username)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDomainAdminDestroy(server *mailcowtest.Server, username string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if server.DomainAdmin(username) != nil {
+			return fmt.Errorf("domain admin %s still exists", username)
+		}
+
+		return nil
+	}
+}
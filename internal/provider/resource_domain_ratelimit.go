@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
+	"strconv"
+)
+
+// ratelimitFrames are the time frames of a mailcow rate limit: second,
+// minute, hour and day.
+var ratelimitFrames = []string{"s", "m", "h", "d"}
+
+type resourceDomainRatelimitType struct{}
+
+func (r resourceDomainRatelimitType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "The outbound rate limit of a domain. Destroying it removes the limit.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"domain": {
+				Type:     types.StringType,
+				Required: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"value": {
+				Type:        types.Int64Type,
+				Description: "The number of messages allowed per frame.",
+				Required:    true,
+				Validators: []tfsdk.AttributeValidator{
+					validators.Int64AtLeastValidator{Min: 1},
+				},
+			},
+			"frame": {
+				Type:        types.StringType,
+				Description: "The time frame of the limit: s, m, h or d.",
+				Required:    true,
+				Validators: []tfsdk.AttributeValidator{
+					validators.StringOneOfValidator{Values: ratelimitFrames},
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceDomainRatelimitType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceDomainRatelimit{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceDomainRatelimit struct {
+	p provider
+}
+
+func (r resourceDomainRatelimit) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan DomainRatelimit
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	// mailcow would silently overwrite a limit set elsewhere
+	_, err := r.p.client.GetDomainRatelimit(ctx, plan.Domain.Value)
+	if err == nil {
+		resp.Diagnostics.AddError("Rate Limit Already Exists", "The domain already has a rate limit in mailcow, import it with the domain name instead.")
+		return
+	}
+	if !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain rate limit, got error: %s", err))
+		return
+	}
+
+	err = r.p.client.EditDomainRatelimit(ctx, plan.Domain.Value, client.RatelimitRequest{
+		Value: strconv.FormatInt(plan.Value.Value, 10),
+		Frame: plan.Frame.Value,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create domain rate limit, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = plan.Domain
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceDomainRatelimit) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state DomainRatelimit
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ratelimit, err := r.p.client.GetDomainRatelimit(ctx, state.Domain.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain rate limit, got error: %s", err))
+		return
+	}
+
+	value, err := ratelimit.Value.Int64()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain rate limit, got value: %s", ratelimit.Value))
+		return
+	}
+
+	state.ID = state.Domain
+	state.Value = types.Int64{Value: value}
+	state.Frame = types.String{Value: ratelimit.Frame}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceDomainRatelimit) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan DomainRatelimit
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.EditDomainRatelimit(ctx, plan.Domain.Value, client.RatelimitRequest{
+		Value: strconv.FormatInt(plan.Value.Value, 10),
+		Frame: plan.Frame.Value,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update domain rate limit, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = plan.Domain
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceDomainRatelimit) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state DomainRatelimit
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteDomainRatelimit(ctx, state.Domain.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete domain rate limit, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r resourceDomainRatelimit) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("domain"), req, resp)
+}
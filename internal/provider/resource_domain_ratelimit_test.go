@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceDomainRatelimit(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainRatelimitDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceDomainRatelimitConfig(server, 0, "h"),
+				ExpectError: regexp.MustCompile(`got: 0`),
+			},
+			{
+				Config:      testAccResourceDomainRatelimitConfig(server, 100, "w"),
+				ExpectError: regexp.MustCompile(`got: "w"`),
+			},
+			{
+				Config: testAccResourceDomainRatelimitConfig(server, 100, "h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_domain_ratelimit.test", "id", "mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_domain_ratelimit.test", "value", "100"),
+					resource.TestCheckResourceAttr("mailcow_domain_ratelimit.test", "frame", "h"),
+					testAccCheckDomainRatelimit(server, "mailcow.tld", "100", "h"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_domain_ratelimit.test",
+				ImportState:             true,
+				ImportStateId:           "mailcow.tld",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				// Changed in the mailcow UI, so Terraform plans to set it back
+				PreConfig:          func() { server.SetDomainRatelimit("mailcow.tld", "5", "s") },
+				Config:             testAccResourceDomainRatelimitConfig(server, 100, "h"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccResourceDomainRatelimitConfig(server, 1000, "d"),
+				Check:  testAccCheckDomainRatelimit(server, "mailcow.tld", "1000", "d"),
+			},
+			{
+				// Cleared in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveRatelimit("mailcow.tld") },
+				Config:    testAccResourceDomainRatelimitConfig(server, 1000, "d"),
+				Check:     testAccCheckDomainRatelimit(server, "mailcow.tld", "1000", "d"),
+			},
+		},
+	})
+}
+
+func testAccResourceDomainRatelimitConfig(server *mailcowtest.Server, value int64, frame string) string {
+	return testAccResourceDomainConfig(server, "Rate limited") + fmt.Sprintf(`
+resource "mailcow_domain_ratelimit" "test" {
+  domain = mailcow_domain.test.domain
+  value  = %d
+  frame  = %q
+}
+`, value, frame)
+}
+
+func testAccCheckDomainRatelimit(server *mailcowtest.Server, domain, value, frame string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ratelimit := server.DomainRatelimit(domain)
+		if ratelimit == nil {
+			return fmt.Errorf("domain %s has no rate limit", domain)
+		}
+		if ratelimit.Value != value || ratelimit.Frame != frame {
+			return fmt.Errorf("domain %s is limited to %s/%s, want %s/%s", domain, ratelimit.Value, ratelimit.Frame, value, frame)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDomainRatelimitDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_domain_ratelimit" {
+				continue
+			}
+
+			if server.DomainRatelimit(rs.Primary.ID) != nil {
+				return fmt.Errorf("domain %s still has a rate limit", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceDomain(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainDestroy(server, "mailcow.tld"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDomainConfig(server, `Quote " and backslash \`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_domain.test", "domain", "mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_domain.test", "description", `Quote " and backslash \`),
+					resource.TestCheckResourceAttr("mailcow_domain.test", "active", "true"),
+					resource.TestCheckResourceAttr("mailcow_domain.test", "quota", "10240"),
+				),
+			},
+			{
+				ResourceName:      "mailcow_domain.test",
+				ImportState:       true,
+				ImportStateId:     "mailcow.tld",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceDomainConfig(server, "Updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_domain.test", "description", "Updated"),
+					testAccCheckDomainExists(server, "mailcow.tld"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveDomain("mailcow.tld") },
+				Config:    testAccResourceDomainConfig(server, "Updated"),
+				Check:     testAccCheckDomainExists(server, "mailcow.tld"),
+			},
+		},
+	})
+}
+
+func testAccResourceDomainConfig(server *mailcowtest.Server, description string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mailcow_domain" "test" {
+  domain               = "mailcow.tld"
+  description          = %q
+  quota                = 10240
+  mailboxes            = 10
+  mailbox_default_size = 1024
+  mailbox_max_size     = 2048
+  aliases              = 100
+}
+`, description)
+}
+
+func testAccCheckDomainExists(server *mailcowtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if server.Domain(name) == nil {
+			return fmt.Errorf("domain %s does not exist", name)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDomainDestroy(server *mailcowtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if server.Domain(name) != nil {
+			return fmt.Errorf("domain %s still exists", name)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
+	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
+	"sort"
+	"strings"
+)
+
+// fail2banConfigID is the ID of the only netfilter configuration of mailcow.
+const fail2banConfigID = "fail2ban"
+
+type resourceFail2banConfigType struct{}
+
+func (r resourceFail2banConfigType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "The fail2ban (netfilter) configuration of mailcow. There is only one, so creating the resource takes it over and destroying it leaves the settings in place.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"ban_time": {
+				Type:        types.Int64Type,
+				Description: "The number of seconds an address stays banned.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultInt64(1800),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.Int64AtLeastValidator{Min: 60},
+				},
+			},
+			"max_attempts": {
+				Type:        types.Int64Type,
+				Description: "The number of failed logins within retry_window that lead to a ban.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultInt64(10),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.Int64AtLeastValidator{Min: 1},
+				},
+			},
+			"retry_window": {
+				Type:        types.Int64Type,
+				Description: "The number of seconds in which failed logins are counted.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultInt64(600),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.Int64AtLeastValidator{Min: 1},
+				},
+			},
+			"netban_ipv4": {
+				Type:        types.Int64Type,
+				Description: "The prefix length of the IPv4 network banned along with an offending address.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultInt64(32),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.Int64BetweenValidator{Min: 8, Max: 32},
+				},
+			},
+			"netban_ipv6": {
+				Type:        types.Int64Type,
+				Description: "The prefix length of the IPv6 network banned along with an offending address.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultInt64(128),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.Int64BetweenValidator{Min: 8, Max: 128},
+				},
+			},
+			"whitelist": {
+				Type: types.SetType{
+					ElemType: types.StringType,
+				},
+				Description: "The networks that are never banned, in CIDR notation. When unset, the whitelist in mailcow is left as is.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.CIDRValidator{},
+				},
+			},
+			"blacklist": {
+				Type: types.SetType{
+					ElemType: types.StringType,
+				},
+				Description: "The networks that are always banned, in CIDR notation. When unset, the blacklist in mailcow is left as is.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.CIDRValidator{},
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceFail2banConfigType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceFail2banConfig{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceFail2banConfig struct {
+	p provider
+}
+
+// fail2banNetworks turns a newline separated list of networks from mailcow
+// into a set.
+func fail2banNetworks(list string) types.Set {
+	networks := strings.Fields(list)
+	sort.Strings(networks)
+
+	set := types.Set{ElemType: types.StringType, Elems: []attr.Value{}}
+	for _, network := range networks {
+		set.Elems = append(set.Elems, types.String{Value: network})
+	}
+
+	return set
+}
+
+// fail2banList turns set into the newline separated list mailcow expects,
+// keeping current when set is unknown because it was left out of the config.
+func fail2banList(ctx context.Context, set types.Set, current string) (string, diag.Diagnostics) {
+	if set.Unknown || set.Null {
+		return current, nil
+	}
+
+	var networks []string
+	diags := set.ElementsAs(ctx, &networks, false)
+	sort.Strings(networks)
+
+	return strings.Join(networks, "\n"), diags
+}
+
+// write applies plan to the configuration in mailcow and returns the
+// resulting state.
+func (r resourceFail2banConfig) write(ctx context.Context, plan Fail2banConfig) (Fail2banConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	current, err := r.p.client.GetFail2ban(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read fail2ban config, got error: %s", err))
+		return plan, diags
+	}
+
+	whitelist, d := fail2banList(ctx, plan.Whitelist, current.Whitelist)
+	diags.Append(d...)
+	blacklist, d := fail2banList(ctx, plan.Blacklist, current.Blacklist)
+	diags.Append(d...)
+	if diags.HasError() {
+		return plan, diags
+	}
+
+	err = r.p.client.EditFail2ban(ctx, client.Fail2banRequest{
+		BanTime:     plan.BanTime.Value,
+		MaxAttempts: plan.MaxAttempts.Value,
+		RetryWindow: plan.RetryWindow.Value,
+		NetbanIPv4:  plan.NetbanIPv4.Value,
+		NetbanIPv6:  plan.NetbanIPv6.Value,
+		Whitelist:   whitelist,
+		Blacklist:   blacklist,
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update fail2ban config, got error: %s", err))
+		return plan, diags
+	}
+
+	result := plan
+	result.ID = types.String{Value: fail2banConfigID}
+	result.Whitelist = fail2banNetworks(whitelist)
+	result.Blacklist = fail2banNetworks(blacklist)
+
+	return result, diags
+}
+
+func (r resourceFail2banConfig) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan Fail2banConfig
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	result, diags := r.write(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceFail2banConfig) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state Fail2banConfig
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fail2ban, err := r.p.client.GetFail2ban(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read fail2ban config, got error: %s", err))
+		return
+	}
+
+	state.BanTime = types.Int64{Value: fail2ban.BanTime}
+	state.MaxAttempts = types.Int64{Value: fail2ban.MaxAttempts}
+	state.RetryWindow = types.Int64{Value: fail2ban.RetryWindow}
+	state.NetbanIPv4 = types.Int64{Value: fail2ban.NetbanIPv4}
+	state.NetbanIPv6 = types.Int64{Value: fail2ban.NetbanIPv6}
+	state.Whitelist = fail2banNetworks(fail2ban.Whitelist)
+	state.Blacklist = fail2banNetworks(fail2ban.Blacklist)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceFail2banConfig) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan Fail2banConfig
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	result, diags := r.write(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete only forgets the configuration, since mailcow cannot run without one.
+func (r resourceFail2banConfig) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	resp.State.RemoveResource(ctx)
+}
+
+func (r resourceFail2banConfig) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	if req.ID != fail2banConfigID {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected %s, got: %s", fail2banConfigID, req.ID))
+		return
+	}
+
+	diags := resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req.ID)
+	resp.Diagnostics.Append(diags...)
+}
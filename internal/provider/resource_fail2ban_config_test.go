@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceFail2banConfig(t *testing.T) {
+	server := testAccServer(t)
+	server.SetFail2ban(mailcowtest.Fail2ban{
+		BanTime:     1800,
+		MaxAttempts: 10,
+		RetryWindow: 600,
+		NetbanIPv4:  32,
+		NetbanIPv6:  128,
+		Blacklist:   "198.51.100.7/32",
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceFail2banConfigConfig(server, 24, `["203.0.113.10"]`, ""),
+				ExpectError: regexp.MustCompile(`"203.0.113.10"`),
+			},
+			{
+				Config:      testAccResourceFail2banConfigConfig(server, 40, `[]`, ""),
+				ExpectError: regexp.MustCompile(`got: 40`),
+			},
+			{
+				// The blacklist is left out, so the one already in mailcow is kept
+				Config: testAccResourceFail2banConfigConfig(server, 24, `["203.0.113.0/24", "2001:db8:1::/48"]`, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "id", "fail2ban"),
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "ban_time", "3600"),
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "max_attempts", "10"),
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "retry_window", "600"),
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "netban_ipv4", "24"),
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "netban_ipv6", "128"),
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "whitelist.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mailcow_fail2ban_config.test", "whitelist.*", "203.0.113.0/24"),
+					resource.TestCheckTypeSetElemAttr("mailcow_fail2ban_config.test", "whitelist.*", "2001:db8:1::/48"),
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "blacklist.#", "1"),
+					resource.TestCheckTypeSetElemAttr("mailcow_fail2ban_config.test", "blacklist.*", "198.51.100.7/32"),
+					testAccCheckFail2banWhitelist(server, "2001:db8:1::/48", "203.0.113.0/24"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_fail2ban_config.test",
+				ImportState:             true,
+				ImportStateId:           "fail2ban",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				// Reordering the whitelist is not a change
+				Config:   testAccResourceFail2banConfigConfig(server, 24, `["2001:db8:1::/48", "203.0.113.0/24"]`, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccResourceFail2banConfigConfig(server, 24, `["203.0.113.0/24"]`, `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "whitelist.#", "1"),
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "blacklist.#", "0"),
+					testAccCheckFail2banWhitelist(server, "203.0.113.0/24"),
+				),
+			},
+			{
+				// Changed in the mailcow UI, so Terraform plans to change it back
+				PreConfig: func() {
+					config := server.Fail2ban()
+					config.BanTime = 600
+					config.Whitelist = ""
+					server.SetFail2ban(config)
+				},
+				Config: testAccResourceFail2banConfigConfig(server, 24, `["203.0.113.0/24"]`, `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_fail2ban_config.test", "ban_time", "3600"),
+					testAccCheckFail2banWhitelist(server, "203.0.113.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceFail2banConfigConfig(server *mailcowtest.Server, netbanIPv4 int64, whitelist string, blacklist string) string {
+	config := testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mailcow_fail2ban_config" "test" {
+  ban_time    = 3600
+  netban_ipv4 = %d
+  whitelist   = %s
+`, netbanIPv4, whitelist)
+	if blacklist != "" {
+		config += fmt.Sprintf("  blacklist   = %s\n", blacklist)
+	}
+
+	return config + "}\n"
+}
+
+func testAccCheckFail2banWhitelist(server *mailcowtest.Server, networks ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		whitelist := server.Fail2ban().Whitelist
+		if whitelist != strings.Join(networks, "\n") {
+			return fmt.Errorf("fail2ban whitelist is %q, want %q", whitelist, strings.Join(networks, "\n"))
+		}
+
+		return nil
+	}
+}
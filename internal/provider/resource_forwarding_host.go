@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
+	"sort"
+)
+
+type resourceForwardingHostType struct{}
+
+func (r resourceForwardingHostType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "A forwarding host whose mail is accepted without being spam-scored again, such as an upstream filtering appliance.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"hostname": {
+				Type:        types.StringType,
+				Description: "A hostname, IP address or CIDR network. mailcow resolves hostnames once, when the host is added.",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"filter_spam": {
+				Type:        types.BoolType,
+				Description: "Filter spam from the host instead of passing it through.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultBool(false),
+				},
+			},
+			"ip_addresses": {
+				Type: types.SetType{
+					ElemType: types.StringType,
+				},
+				Description: "The addresses mailcow stored for the host.",
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceForwardingHostType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceForwardingHost{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceForwardingHost struct {
+	p provider
+}
+
+func (r resourceForwardingHost) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan ForwardingHost
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.AddForwardingHost(ctx, client.ForwardingHostRequest{
+		Hostname:   plan.Hostname.Value,
+		FilterSpam: boolToString(plan.FilterSpam.Value),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create forwarding host, got error: %s", err))
+		return
+	}
+
+	hosts, err := r.p.client.GetForwardingHost(ctx, plan.Hostname.Value)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read forwarding host, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = plan.Hostname
+	result.IPAddresses = forwardingHostIPs(*hosts)
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceForwardingHost) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state ForwardingHost
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hosts, err := r.p.client.GetForwardingHost(ctx, state.Hostname.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read forwarding host, got error: %s", err))
+		return
+	}
+
+	state.ID = state.Hostname
+	state.FilterSpam = types.Bool{Value: (*hosts)[0].KeepSpam == "no"}
+	state.IPAddresses = forwardingHostIPs(*hosts)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceForwardingHost) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan ForwardingHost
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	hosts, err := r.p.client.GetForwardingHost(ctx, plan.Hostname.Value)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read forwarding host, got error: %s", err))
+		return
+	}
+
+	keepSpam := "yes"
+	if plan.FilterSpam.Value {
+		keepSpam = "no"
+	}
+
+	err = r.p.client.EditForwardingHosts(ctx, forwardingHostAddresses(*hosts), client.ForwardingHostEditRequest{
+		KeepSpam: keepSpam,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update forwarding host, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = plan.Hostname
+	result.IPAddresses = forwardingHostIPs(*hosts)
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceForwardingHost) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state ForwardingHost
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	// Delete the addresses stored now, which is what mailcow matches on
+	hosts, err := r.p.client.GetForwardingHost(ctx, state.Hostname.Value)
+	if err == nil {
+		err = r.p.client.DeleteForwardingHosts(ctx, forwardingHostAddresses(*hosts))
+	}
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete forwarding host, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r resourceForwardingHost) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("hostname"), req, resp)
+}
+
+func forwardingHostAddresses(hosts []client.ForwardingHostResponse) []string {
+	var ips []string
+	for _, host := range hosts {
+		ips = append(ips, host.Host)
+	}
+	sort.Strings(ips)
+
+	return ips
+}
+
+func forwardingHostIPs(hosts []client.ForwardingHostResponse) types.Set {
+	ips := types.Set{ElemType: types.StringType, Elems: []attr.Value{}}
+	for _, ip := range forwardingHostAddresses(hosts) {
+		ips.Elems = append(ips.Elems, types.String{Value: ip})
+	}
+
+	return ips
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceForwardingHost(t *testing.T) {
+	server := testAccServer(t)
+	server.SetDNS("filter.example.net", "192.0.2.10", "2001:db8::10")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckForwardingHostDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceForwardingHostConfig(server, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_forwarding_host.test", "id", "filter.example.net"),
+					resource.TestCheckResourceAttr("mailcow_forwarding_host.test", "filter_spam", "false"),
+					resource.TestCheckResourceAttr("mailcow_forwarding_host.test", "ip_addresses.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mailcow_forwarding_host.test", "ip_addresses.*", "192.0.2.10"),
+					resource.TestCheckTypeSetElemAttr("mailcow_forwarding_host.test", "ip_addresses.*", "2001:db8::10"),
+					resource.TestCheckResourceAttr("mailcow_forwarding_host.network", "ip_addresses.#", "1"),
+					resource.TestCheckTypeSetElemAttr("mailcow_forwarding_host.network", "ip_addresses.*", "198.51.100.0/24"),
+					testAccCheckForwardingHostKeepSpam(server, "filter.example.net", "yes"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_forwarding_host.test",
+				ImportState:             true,
+				ImportStateId:           "filter.example.net",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				Config: testAccResourceForwardingHostConfig(server, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_forwarding_host.test", "filter_spam", "true"),
+					resource.TestCheckResourceAttr("mailcow_forwarding_host.test", "ip_addresses.#", "2"),
+					testAccCheckForwardingHostKeepSpam(server, "filter.example.net", "no"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() {
+					server.RemoveForwardingHost("192.0.2.10")
+					server.RemoveForwardingHost("2001:db8::10")
+				},
+				Config: testAccResourceForwardingHostConfig(server, true),
+				Check:  testAccCheckForwardingHostKeepSpam(server, "filter.example.net", "no"),
+			},
+		},
+	})
+}
+
+func testAccResourceForwardingHostConfig(server *mailcowtest.Server, filterSpam bool) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mailcow_forwarding_host" "test" {
+  hostname    = "filter.example.net"
+  filter_spam = %t
+}
+
+resource "mailcow_forwarding_host" "network" {
+  hostname = "198.51.100.0/24"
+}
+`, filterSpam)
+}
+
+func testAccCheckForwardingHostKeepSpam(server *mailcowtest.Server, hostname, keepSpam string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		hosts := server.ForwardingHosts(hostname)
+		if len(hosts) == 0 {
+			return fmt.Errorf("forwarding host %s does not exist", hostname)
+		}
+		for _, host := range hosts {
+			if host.KeepSpam != keepSpam {
+				return fmt.Errorf("forwarding host %s has keep_spam %s, want %s", host.Host, host.KeepSpam, keepSpam)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckForwardingHostDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_forwarding_host" {
+				continue
+			}
+
+			if len(server.ForwardingHosts(rs.Primary.ID)) != 0 {
+				return fmt.Errorf("forwarding host %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
+	"strconv"
+)
+
+type resourceMailboxType struct{}
+
+func (r resourceMailboxType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "A mailbox of a domain. mailcow never returns the password, so after an import the first plan shows an update that sets it to the configured value.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"email": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"local_part": {
+				Type:        types.StringType,
+				Description: "The part of the email address before the @",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"domain": {
+				Type:        types.StringType,
+				Description: "The @domain.tld part of the email address",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"name": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"quota": {
+				Type:        types.Int64Type,
+				Description: "Mailbox quota in MiB, 0 for the domain maximum",
+				Required:    true,
+			},
+			"password": {
+				Type:        types.StringType,
+				Description: "The mailbox password. It cannot be read back from mailcow, so changes made outside Terraform are not detected.",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"active": {
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultBool(true),
+				},
+			},
+			"force_password_update": {
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultBool(false),
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceMailboxType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceMailbox{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceMailbox struct {
+	p provider
+}
+
+func boolToString(value bool) string {
+	if value {
+		return "1"
+	}
+
+	return "0"
+}
+
+func (r resourceMailbox) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan Mailbox
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.AddMailbox(ctx, client.MailboxRequest{
+		LocalPart:           plan.LocalPart.Value,
+		Domain:              plan.Domain.Value,
+		Name:                plan.Name.Value,
+		Quota:               strconv.FormatInt(plan.QuotaMB.Value, 10),
+		Password:            plan.Password.Value,
+		PasswordConfirm:     plan.Password.Value,
+		Active:              boolToString(plan.Active.Value),
+		ForcePasswordUpdate: boolToString(plan.ForcePasswordUpdate.Value),
+	})
+	if client.IsAlreadyExists(err) {
+		resp.Diagnostics.AddError("Mailbox Already Exists", fmt.Sprintf("The mailbox already exists in mailcow, import it with its email address instead: %s", err))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create mailbox, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.Email = types.String{Value: plan.LocalPart.Value + "@" + plan.Domain.Value}
+	result.ID = result.Email
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceMailbox) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state Mailbox
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mailbox, err := r.p.client.GetMailbox(ctx, state.Email.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read mailbox, got error: %s", err))
+		return
+	}
+
+	state.ID = types.String{Value: mailbox.Email}
+	state.Email = types.String{Value: mailbox.Email}
+	state.LocalPart = types.String{Value: mailbox.Username}
+	state.Domain = types.String{Value: mailbox.Domain}
+	state.Name = types.String{Value: mailbox.Name}
+	state.QuotaMB = types.Int64{Value: mailbox.Quota / 1024 / 1024}
+	state.Active = types.Bool{Value: mailbox.Active == 1}
+	state.ForcePasswordUpdate = types.Bool{Value: mailbox.Attributes.ForcePasswordUpdate == "1"}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceMailbox) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan Mailbox
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state Mailbox
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	mailbox := client.MailboxRequest{
+		Name:                plan.Name.Value,
+		Quota:               strconv.FormatInt(plan.QuotaMB.Value, 10),
+		Active:              boolToString(plan.Active.Value),
+		ForcePasswordUpdate: boolToString(plan.ForcePasswordUpdate.Value),
+	}
+
+	// Only send the password when it changed, otherwise mailcow rehashes it
+	if !plan.Password.Equal(state.Password) {
+		mailbox.Password = plan.Password.Value
+		mailbox.PasswordConfirm = plan.Password.Value
+	}
+
+	err := r.p.client.EditMailbox(ctx, state.Email.Value, mailbox)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update mailbox, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.Email = state.Email
+	result.ID = state.Email
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceMailbox) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state Mailbox
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteMailbox(ctx, state.Email.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete mailbox, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts the email address. The password cannot be imported, so
+// the first apply after an import writes the configured password.
+func (r resourceMailbox) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("email"), req, resp)
+}
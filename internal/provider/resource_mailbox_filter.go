@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
+	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
+	"strconv"
+	"strings"
+)
+
+type resourceMailboxFilterType struct{}
+
+func (r resourceMailboxFilterType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "A Sieve filter of a mailbox. mailcow runs only one active filter of each type per mailbox, so activating a filter deactivates the others.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.Int64Type,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"username": {
+				Type:        types.StringType,
+				Description: "The email address of the mailbox.",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"filter_type": {
+				Type:        types.StringType,
+				Description: "Whether the filter runs before (prefilter) or after (postfilter) the filters of the user.",
+				Required:    true,
+				Validators: []tfsdk.AttributeValidator{
+					validators.StringOneOfValidator{Values: []string{"prefilter", "postfilter"}},
+				},
+			},
+			"description": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"script_data": {
+				Type:        types.StringType,
+				Description: "The Sieve script, checked for syntax errors at plan time.",
+				Required:    true,
+				Validators: []tfsdk.AttributeValidator{
+					validators.SieveValidator{},
+				},
+			},
+			"active": {
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultBool(true),
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceMailboxFilterType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceMailboxFilter{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceMailboxFilter struct {
+	p provider
+}
+
+func (r resourceMailboxFilter) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan MailboxFilter
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	id, err := r.p.client.AddMailboxFilter(ctx, client.MailboxFilterRequest{
+		Username:    plan.Username.Value,
+		FilterType:  plan.FilterType.Value,
+		Description: plan.Description.Value,
+		ScriptData:  plan.ScriptData.Value,
+		Active:      boolToString(plan.Active.Value),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create mailbox filter, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = types.Int64{Value: id}
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceMailboxFilter) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state MailboxFilter
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := r.p.client.GetMailboxFilter(ctx, state.Username.Value, state.ID.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read mailbox filter, got error: %s", err))
+		return
+	}
+
+	state.Username = types.String{Value: filter.Username}
+	state.FilterType = types.String{Value: filter.FilterType}
+	state.Description = types.String{Value: filter.Description}
+	state.ScriptData = types.String{Value: filter.ScriptData}
+	state.Active = types.Bool{Value: filter.ScriptName == "active"}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceMailboxFilter) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan MailboxFilter
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state MailboxFilter
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.EditMailboxFilter(ctx, state.ID.Value, client.MailboxFilterRequest{
+		FilterType:  plan.FilterType.Value,
+		Description: plan.Description.Value,
+		ScriptData:  plan.ScriptData.Value,
+		Active:      boolToString(plan.Active.Value),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update mailbox filter, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = state.ID
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceMailboxFilter) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state MailboxFilter
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteMailboxFilter(ctx, state.ID.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete mailbox filter, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts <username>/<ID>, as mailcow lists filters per mailbox.
+func (r resourceMailboxFilter) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected <username>/<ID>, got: %s", req.ID))
+		return
+	}
+
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected a numeric filter ID, got: %s", parts[1]))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("username"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), id)...)
+}
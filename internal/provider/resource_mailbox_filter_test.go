@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+const testAccJunkFilter = `require "fileinto";
+if header :contains "X-Spam-Flag" "YES" {
+  fileinto "Junk";
+  stop;
+}
+`
+
+const testAccForwardFilter = `require "copy";
+# Keep a copy and forward everything
+redirect :copy "archive@example.com";
+keep;
+`
+
+func TestAccResourceMailboxFilter(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMailboxFilterDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				// fileinto needs require "fileinto"
+				Config:      testAccResourceMailboxFilterConfig(server, "prefilter", "if true {\n  fileinto \"Junk\";\n}\n"),
+				ExpectError: regexp.MustCompile(`require "fileinto"`),
+			},
+			{
+				Config:      testAccResourceMailboxFilterConfig(server, "prefilter", "keep\nstop;\n"),
+				ExpectError: regexp.MustCompile(`line 2: expected ; after keep`),
+			},
+			{
+				Config:      testAccResourceMailboxFilterConfig(server, "filter", testAccJunkFilter),
+				ExpectError: regexp.MustCompile(`got: "filter"`),
+			},
+			{
+				Config: testAccResourceMailboxFilterConfig(server, "prefilter", testAccJunkFilter),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_mailbox_filter.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_mailbox_filter.test", "username", "user@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_mailbox_filter.test", "filter_type", "prefilter"),
+					resource.TestCheckResourceAttr("mailcow_mailbox_filter.test", "description", "Standard rules"),
+					resource.TestCheckResourceAttr("mailcow_mailbox_filter.test", "script_data", testAccJunkFilter),
+					resource.TestCheckResourceAttr("mailcow_mailbox_filter.test", "active", "true"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_mailbox_filter.test",
+				ImportState:             true,
+				ImportStateId:           "user@mailcow.tld/1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				Config: testAccResourceMailboxFilterConfig(server, "postfilter", testAccForwardFilter),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_mailbox_filter.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_mailbox_filter.test", "filter_type", "postfilter"),
+					testAccCheckMailboxFilterScript(server, 1, testAccForwardFilter),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveMailboxFilter(1) },
+				Config:    testAccResourceMailboxFilterConfig(server, "postfilter", testAccForwardFilter),
+				Check:     testAccCheckMailboxFilterScript(server, 2, testAccForwardFilter),
+			},
+		},
+	})
+}
+
+func testAccResourceMailboxFilterConfig(server *mailcowtest.Server, filterType string, script string) string {
+	return testAccResourceMailboxConfig(server, "User", "password") + fmt.Sprintf(`
+resource "mailcow_mailbox_filter" "test" {
+  username    = mailcow_mailbox.test.email
+  filter_type = %q
+  description = "Standard rules"
+  script_data = <<-EOT
+%sEOT
+}
+`, filterType, script)
+}
+
+func testAccCheckMailboxFilterScript(server *mailcowtest.Server, id int64, script string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		filter := server.MailboxFilter(id)
+		if filter == nil {
+			return fmt.Errorf("filter %d does not exist", id)
+		}
+		if filter.ScriptData != script {
+			return fmt.Errorf("filter %d has script %q, want %q", id, filter.ScriptData, script)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMailboxFilterDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_mailbox_filter" {
+				continue
+			}
+
+			id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			if server.MailboxFilter(id) != nil {
+				return fmt.Errorf("filter %d still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
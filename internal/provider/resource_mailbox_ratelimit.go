@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
+	"strconv"
+)
+
+type resourceMailboxRatelimitType struct{}
+
+func (r resourceMailboxRatelimitType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "The outbound rate limit of a mailbox. Destroying it removes the limit.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"mailbox": {
+				Type:        types.StringType,
+				Description: "The email address of the mailbox.",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"value": {
+				Type:        types.Int64Type,
+				Description: "The number of messages allowed per frame.",
+				Required:    true,
+				Validators: []tfsdk.AttributeValidator{
+					validators.Int64AtLeastValidator{Min: 1},
+				},
+			},
+			"frame": {
+				Type:        types.StringType,
+				Description: "The time frame of the limit: s, m, h or d.",
+				Required:    true,
+				Validators: []tfsdk.AttributeValidator{
+					validators.StringOneOfValidator{Values: ratelimitFrames},
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceMailboxRatelimitType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceMailboxRatelimit{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceMailboxRatelimit struct {
+	p provider
+}
+
+func (r resourceMailboxRatelimit) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan MailboxRatelimit
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	// mailcow would silently overwrite a limit set elsewhere
+	_, err := r.p.client.GetMailboxRatelimit(ctx, plan.Mailbox.Value)
+	if err == nil {
+		resp.Diagnostics.AddError("Rate Limit Already Exists", "The mailbox already has a rate limit in mailcow, import it with the email address instead.")
+		return
+	}
+	if !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read mailbox rate limit, got error: %s", err))
+		return
+	}
+
+	err = r.p.client.EditMailboxRatelimit(ctx, plan.Mailbox.Value, client.RatelimitRequest{
+		Value: strconv.FormatInt(plan.Value.Value, 10),
+		Frame: plan.Frame.Value,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create mailbox rate limit, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = plan.Mailbox
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceMailboxRatelimit) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state MailboxRatelimit
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ratelimit, err := r.p.client.GetMailboxRatelimit(ctx, state.Mailbox.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read mailbox rate limit, got error: %s", err))
+		return
+	}
+
+	value, err := ratelimit.Value.Int64()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read mailbox rate limit, got value: %s", ratelimit.Value))
+		return
+	}
+
+	state.ID = state.Mailbox
+	state.Value = types.Int64{Value: value}
+	state.Frame = types.String{Value: ratelimit.Frame}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceMailboxRatelimit) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan MailboxRatelimit
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.EditMailboxRatelimit(ctx, plan.Mailbox.Value, client.RatelimitRequest{
+		Value: strconv.FormatInt(plan.Value.Value, 10),
+		Frame: plan.Frame.Value,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update mailbox rate limit, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = plan.Mailbox
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceMailboxRatelimit) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state MailboxRatelimit
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteMailboxRatelimit(ctx, state.Mailbox.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete mailbox rate limit, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r resourceMailboxRatelimit) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("mailbox"), req, resp)
+}
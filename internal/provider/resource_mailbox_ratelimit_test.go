@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceMailboxRatelimit(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMailboxRatelimitDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceMailboxRatelimitConfig(server, 10, "m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_mailbox_ratelimit.test", "id", "user@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_mailbox_ratelimit.test", "mailbox", "user@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_mailbox_ratelimit.test", "value", "10"),
+					resource.TestCheckResourceAttr("mailcow_mailbox_ratelimit.test", "frame", "m"),
+					testAccCheckMailboxRatelimit(server, "user@mailcow.tld", "10", "m"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_mailbox_ratelimit.test",
+				ImportState:             true,
+				ImportStateId:           "user@mailcow.tld",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				Config: testAccResourceMailboxRatelimitConfig(server, 200, "d"),
+				Check:  testAccCheckMailboxRatelimit(server, "user@mailcow.tld", "200", "d"),
+			},
+			{
+				// Cleared in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveRatelimit("user@mailcow.tld") },
+				Config:    testAccResourceMailboxRatelimitConfig(server, 200, "d"),
+				Check:     testAccCheckMailboxRatelimit(server, "user@mailcow.tld", "200", "d"),
+			},
+		},
+	})
+}
+
+func testAccResourceMailboxRatelimitConfig(server *mailcowtest.Server, value int64, frame string) string {
+	return testAccResourceMailboxConfig(server, "User", "password") + fmt.Sprintf(`
+resource "mailcow_mailbox_ratelimit" "test" {
+  mailbox = mailcow_mailbox.test.email
+  value   = %d
+  frame   = %q
+}
+`, value, frame)
+}
+
+func testAccCheckMailboxRatelimit(server *mailcowtest.Server, username, value, frame string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ratelimit := server.MailboxRatelimit(username)
+		if ratelimit == nil {
+			return fmt.Errorf("mailbox %s has no rate limit", username)
+		}
+		if ratelimit.Value != value || ratelimit.Frame != frame {
+			return fmt.Errorf("mailbox %s is limited to %s/%s, want %s/%s", username, ratelimit.Value, ratelimit.Frame, value, frame)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMailboxRatelimitDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_mailbox_ratelimit" {
+				continue
+			}
+
+			if server.MailboxRatelimit(rs.Primary.ID) != nil {
+				return fmt.Errorf("mailbox %s still has a rate limit", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceMailbox(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceMailboxConfig(server, "User", "first-password"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_mailbox.test", "email", "user@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_mailbox.test", "name", "User"),
+					resource.TestCheckResourceAttr("mailcow_mailbox.test", "quota", "512"),
+					resource.TestCheckResourceAttr("mailcow_mailbox.test", "active", "true"),
+					resource.TestCheckResourceAttr("mailcow_mailbox.test", "force_password_update", "false"),
+				),
+			},
+			{
+				ResourceName:      "mailcow_mailbox.test",
+				ImportState:       true,
+				ImportStateId:     "user@mailcow.tld",
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"password",
+					"timeouts",
+				},
+			},
+			{
+				Config: testAccResourceMailboxConfig(server, "Renamed", "second-password"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_mailbox.test", "name", "Renamed"),
+					testAccCheckMailboxPassword(server, "user@mailcow.tld", "second-password"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveMailbox("user@mailcow.tld") },
+				Config:    testAccResourceMailboxConfig(server, "Renamed", "second-password"),
+				Check:     testAccCheckMailboxPassword(server, "user@mailcow.tld", "second-password"),
+			},
+		},
+	})
+}
+
+func testAccResourceMailboxConfig(server *mailcowtest.Server, name, password string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mailcow_domain" "test" {
+  domain               = "mailcow.tld"
+  description          = "Mailbox test"
+  quota                = 10240
+  mailboxes            = 10
+  mailbox_default_size = 1024
+  mailbox_max_size     = 2048
+  aliases              = 100
+}
+
+resource "mailcow_mailbox" "test" {
+  local_part = "user"
+  domain     = mailcow_domain.test.domain
+  name       = %q
+  quota      = 512
+  password   = %q
+}
+`, name, password)
+}
+
+func testAccCheckMailboxPassword(server *mailcowtest.Server, username, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		mailbox := server.Mailbox(username)
+		if mailbox == nil {
+			return fmt.Errorf("mailbox %s does not exist", username)
+		}
+
+		if mailbox.Password != password {
+			return fmt.Errorf("mailbox %s has an unexpected password", username)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
+)
+
+type resourceOAuth2ClientType struct{}
+
+func (r resourceOAuth2ClientType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "A client of the mailcow OAuth2 provider, for applications signing users in with their mailbox.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.Int64Type,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"redirect_uri": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"client_id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"client_secret": {
+				Type:      types.StringType,
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+					plan_modifiers.UnknownWhenChanged{Attribute: "regenerate_trigger"},
+				},
+			},
+			"regenerate_trigger": {
+				Type:        types.StringType,
+				Description: "Any value. Changing it makes mailcow generate a new client secret.",
+				Optional:    true,
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceOAuth2ClientType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceOAuth2Client{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceOAuth2Client struct {
+	p provider
+}
+
+func (r resourceOAuth2Client) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan OAuth2Client
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	id, err := r.p.client.AddOAuth2Client(ctx, client.OAuth2ClientRequest{
+		RedirectURI: plan.RedirectURI.Value,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create OAuth2 client, got error: %s", err))
+		return
+	}
+
+	// The credentials are generated by mailcow
+	oauth2Client, err := r.p.client.GetOAuth2Client(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read OAuth2 client, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = types.Int64{Value: id}
+	result.ClientID = types.String{Value: oauth2Client.ClientID}
+	result.ClientSecret = types.String{Value: oauth2Client.ClientSecret}
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceOAuth2Client) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state OAuth2Client
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	oauth2Client, err := r.p.client.GetOAuth2Client(ctx, state.ID.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read OAuth2 client, got error: %s", err))
+		return
+	}
+
+	state.RedirectURI = types.String{Value: oauth2Client.RedirectURI}
+	state.ClientID = types.String{Value: oauth2Client.ClientID}
+	state.ClientSecret = types.String{Value: oauth2Client.ClientSecret}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceOAuth2Client) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan OAuth2Client
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state OAuth2Client
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	oauth2Client := client.OAuth2ClientRequest{
+		RedirectURI: plan.RedirectURI.Value,
+	}
+	if !plan.RegenerateTrigger.Equal(state.RegenerateTrigger) {
+		oauth2Client.RenewSecret = "1"
+	}
+
+	err := r.p.client.EditOAuth2Client(ctx, state.ID.Value, oauth2Client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update OAuth2 client, got error: %s", err))
+		return
+	}
+
+	updated, err := r.p.client.GetOAuth2Client(ctx, state.ID.Value)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read OAuth2 client, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = state.ID
+	result.ClientID = types.String{Value: updated.ClientID}
+	result.ClientSecret = types.String{Value: updated.ClientSecret}
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceOAuth2Client) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state OAuth2Client
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteOAuth2Client(ctx, state.ID.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete OAuth2 client, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r resourceOAuth2Client) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	importInt64ID(ctx, "OAuth2 client", req, resp)
+}
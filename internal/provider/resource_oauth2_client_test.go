@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceOAuth2Client(t *testing.T) {
+	server := testAccServer(t)
+
+	var secret string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckOAuth2ClientDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceOAuth2ClientConfig(server, "https://app.example/callback", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_oauth2_client.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_oauth2_client.test", "redirect_uri", "https://app.example/callback"),
+					testAccCheckOAuth2ClientCredentials(server, 1, &secret),
+				),
+			},
+			{
+				ResourceName:            "mailcow_oauth2_client.test",
+				ImportState:             true,
+				ImportStateId:           "1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				Config: testAccResourceOAuth2ClientConfig(server, "https://app.example/oauth/callback", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_oauth2_client.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_oauth2_client.test", "redirect_uri", "https://app.example/oauth/callback"),
+					resource.TestCheckResourceAttrPtr("mailcow_oauth2_client.test", "client_secret", &secret),
+				),
+			},
+			{
+				Config: testAccResourceOAuth2ClientConfig(server, "https://app.example/oauth/callback", "2022-06"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_oauth2_client.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_oauth2_client.test", "regenerate_trigger", "2022-06"),
+					testAccCheckOAuth2ClientSecretChanged(&secret),
+					testAccCheckOAuth2ClientCredentials(server, 1, &secret),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveOAuth2Client(1) },
+				Config:    testAccResourceOAuth2ClientConfig(server, "https://app.example/oauth/callback", "2022-06"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_oauth2_client.test", "id", "2"),
+					testAccCheckOAuth2ClientCredentials(server, 2, &secret),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceOAuth2ClientConfig(server *mailcowtest.Server, redirectURI string, trigger string) string {
+	config := testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mailcow_oauth2_client" "test" {
+  redirect_uri = %q
+`, redirectURI)
+	if trigger != "" {
+		config += fmt.Sprintf("  regenerate_trigger = %q\n", trigger)
+	}
+
+	return config + "}\n"
+}
+
+// testAccCheckOAuth2ClientCredentials compares the credentials in state with
+// those generated by the server and remembers the secret for later steps.
+func testAccCheckOAuth2ClientCredentials(server *mailcowtest.Server, id int64, secret *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		oauth2Client := server.OAuth2Client(id)
+		if oauth2Client == nil {
+			return fmt.Errorf("OAuth2 client %d does not exist", id)
+		}
+
+		attributes := s.RootModule().Resources["mailcow_oauth2_client.test"].Primary.Attributes
+		if attributes["client_id"] != oauth2Client.ClientID {
+			return fmt.Errorf("OAuth2 client %d has client_id %s in state, want %s", id, attributes["client_id"], oauth2Client.ClientID)
+		}
+		if attributes["client_secret"] != oauth2Client.ClientSecret {
+			return fmt.Errorf("OAuth2 client %d has a client_secret in state that differs from mailcow", id)
+		}
+
+		*secret = oauth2Client.ClientSecret
+		return nil
+	}
+}
+
+func testAccCheckOAuth2ClientSecretChanged(secret *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if s.RootModule().Resources["mailcow_oauth2_client.test"].Primary.Attributes["client_secret"] == *secret {
+			return fmt.Errorf("OAuth2 client secret was not regenerated")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckOAuth2ClientDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_oauth2_client" {
+				continue
+			}
+
+			id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			if server.OAuth2Client(id) != nil {
+				return fmt.Errorf("OAuth2 client %d still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
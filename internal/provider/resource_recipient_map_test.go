@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceRecipientMap(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRecipientMapDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceRecipientMapConfig(server, "new@mailcow.tld", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_recipient_map.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_recipient_map.test", "recipient_map_old", "old@example.org"),
+					resource.TestCheckResourceAttr("mailcow_recipient_map.test", "recipient_map_new", "new@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_recipient_map.test", "active", "true"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_recipient_map.test",
+				ImportState:             true,
+				ImportStateId:           "1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				Config: testAccResourceRecipientMapConfig(server, "other@mailcow.tld", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_recipient_map.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_recipient_map.test", "active", "false"),
+					testAccCheckRecipientMapNew(server, 1, "other@mailcow.tld"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveRecipientMap(1) },
+				Config:    testAccResourceRecipientMapConfig(server, "other@mailcow.tld", false),
+				Check:     testAccCheckRecipientMapNew(server, 2, "other@mailcow.tld"),
+			},
+		},
+	})
+}
+
+func testAccResourceRecipientMapConfig(server *mailcowtest.Server, recipient string, active bool) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mailcow_recipient_map" "test" {
+  recipient_map_old = "old@example.org"
+  recipient_map_new = %q
+  active            = %t
+}
+`, recipient, active)
+}
+
+func testAccCheckRecipientMapNew(server *mailcowtest.Server, id int64, recipient string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		recipientMap := server.RecipientMap(id)
+		if recipientMap == nil {
+			return fmt.Errorf("recipient map %d does not exist", id)
+		}
+		if recipientMap.New != recipient {
+			return fmt.Errorf("recipient map %d rewrites to %s, want %s", id, recipientMap.New, recipient)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRecipientMapDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_recipient_map" {
+				continue
+			}
+
+			id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			if server.RecipientMap(id) != nil {
+				return fmt.Errorf("recipient map %d still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceRelayhost(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRelayhostDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceRelayhostConfig(server, "relay", "secret-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_relayhost.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_relayhost.test", "hostname", "[smtp.example.com]:587"),
+					resource.TestCheckResourceAttr("mailcow_relayhost.test", "username", "relay"),
+					resource.TestCheckResourceAttr("mailcow_relayhost.test", "active", "true"),
+					resource.TestCheckResourceAttr("mailcow_domain.test", "relayhost_id", "1"),
+					testAccCheckDomainRelayhost(server, "mailcow.tld", "1"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_relayhost.test",
+				ImportState:             true,
+				ImportStateId:           "1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "timeouts"},
+			},
+			{
+				Config: testAccResourceRelayhostConfig(server, "other", "secret-2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_relayhost.test", "username", "other"),
+					testAccCheckRelayhostPassword(server, 1, "secret-2"),
+				),
+			},
+			{
+				// Removing the credentials clears them in mailcow too
+				Config: testAccResourceRelayhostConfig(server, "", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_relayhost.test", "username", ""),
+					resource.TestCheckNoResourceAttr("mailcow_relayhost.test", "password"),
+					testAccCheckRelayhostPassword(server, 1, ""),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create and
+				// points the domain at the new relayhost
+				PreConfig: func() { server.RemoveRelayhost(1) },
+				Config:    testAccResourceRelayhostConfig(server, "other", "secret-2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_relayhost.test", "id", "2"),
+					testAccCheckDomainRelayhost(server, "mailcow.tld", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceRelayhostConfig(server *mailcowtest.Server, username, password string) string {
+	credentials := ""
+	if username != "" {
+		credentials = fmt.Sprintf("username = %q\n  password = %q", username, password)
+	}
+
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mailcow_relayhost" "test" {
+  hostname = "[smtp.example.com]:587"
+  %s
+}
+
+resource "mailcow_domain" "test" {
+  domain               = "mailcow.tld"
+  description          = "Example"
+  quota                = 10240
+  mailboxes            = 10
+  mailbox_default_size = 1024
+  mailbox_max_size     = 2048
+  aliases              = 100
+  relayhost_id         = mailcow_relayhost.test.id
+}
+`, credentials)
+}
+
+func testAccCheckDomainRelayhost(server *mailcowtest.Server, name, relayhost string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		domain := server.Domain(name)
+		if domain == nil {
+			return fmt.Errorf("domain %s does not exist", name)
+		}
+		if domain.RelayHost != relayhost {
+			return fmt.Errorf("domain %s has relayhost %s, want %s", name, domain.RelayHost, relayhost)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRelayhostPassword(server *mailcowtest.Server, id int64, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		relayhost := server.Relayhost(id)
+		if relayhost == nil {
+			return fmt.Errorf("relayhost %d does not exist", id)
+		}
+		if relayhost.Password != password {
+			return fmt.Errorf("relayhost %d has an outdated password", id)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRelayhostDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_relayhost" {
+				continue
+			}
+
+			id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			if server.Relayhost(id) != nil {
+				return fmt.Errorf("relayhost %d still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckResourceDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceResourceConfig(server, "Room 1", "room", 0),
+				ExpectError: regexp.MustCompile(`got: "room"`),
+			},
+			{
+				Config: testAccResourceResourceConfig(server, "Room 1", "location", 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_resource.test", "id", "Room1@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_resource.test", "name", "Room1@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_resource.test", "description", "Room 1"),
+					resource.TestCheckResourceAttr("mailcow_resource.test", "kind", "location"),
+					resource.TestCheckResourceAttr("mailcow_resource.test", "multiple_bookings", "0"),
+					resource.TestCheckResourceAttr("mailcow_resource.test", "active", "true"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_resource.test",
+				ImportState:             true,
+				ImportStateId:           "Room1@mailcow.tld",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				// A new description keeps the name the resource was created with
+				Config: testAccResourceResourceConfig(server, "Board room", "location", -1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_resource.test", "name", "Room1@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_resource.test", "description", "Board room"),
+					resource.TestCheckResourceAttr("mailcow_resource.test", "multiple_bookings", "-1"),
+					testAccCheckResourceBookings(server, "Room1@mailcow.tld", -1),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveResource("Room1@mailcow.tld") },
+				Config:    testAccResourceResourceConfig(server, "Board room", "location", -1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_resource.test", "name", "Boardroom@mailcow.tld"),
+					testAccCheckResourceBookings(server, "Boardroom@mailcow.tld", -1),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceResourceConfig(server *mailcowtest.Server, description string, kind string, multipleBookings int64) string {
+	return testAccResourceDomainConfig(server, "Resources") + fmt.Sprintf(`
+resource "mailcow_resource" "test" {
+  description       = %q
+  domain            = mailcow_domain.test.domain
+  kind              = %q
+  multiple_bookings = %d
+}
+`, description, kind, multipleBookings)
+}
+
+func testAccCheckResourceBookings(server *mailcowtest.Server, name string, multipleBookings int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r := server.Resource(name)
+		if r == nil {
+			return fmt.Errorf("resource %s does not exist", name)
+		}
+		if r.MultipleBookings != multipleBookings {
+			return fmt.Errorf("resource %s allows %d bookings, want %d", name, r.MultipleBookings, multipleBookings)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckResourceDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_resource" {
+				continue
+			}
+
+			if server.Resource(rs.Primary.ID) != nil {
+				return fmt.Errorf("resource %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
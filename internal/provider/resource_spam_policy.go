@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
+	"strconv"
+	"strings"
+)
+
+type resourceSpamPolicyType struct{}
+
+func (r resourceSpamPolicyType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "A whitelist or blacklist entry of a domain or mailbox. Entries cannot be edited, so every change replaces the entry.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.Int64Type,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"domain": {
+				Type:        types.StringType,
+				Description: "The domain the entry applies to. Exactly one of domain and mailbox must be set.",
+				Optional:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"mailbox": {
+				Type:        types.StringType,
+				Description: "The email address of the mailbox the entry applies to. Exactly one of domain and mailbox must be set.",
+				Optional:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"type": {
+				Type:        types.StringType,
+				Description: "wl to always accept mail from the sender, bl to always reject it.",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.StringOneOfValidator{Values: []string{"wl", "bl"}},
+				},
+			},
+			"from": {
+				Type:        types.StringType,
+				Description: "The sender, such as \"user@example.org\", \"*@example.org\" or \"example.org\".",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceSpamPolicyType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceSpamPolicy{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceSpamPolicy struct {
+	p provider
+}
+
+func (r resourceSpamPolicy) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var config SpamPolicy
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Domain.Null == config.Mailbox.Null {
+		resp.Diagnostics.AddError(
+			"Invalid Attribute Combination",
+			"Exactly one of domain and mailbox must be set.",
+		)
+	}
+}
+
+func (r resourceSpamPolicy) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan SpamPolicy
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	var id int64
+	var err error
+	if !plan.Domain.Null {
+		id, err = r.p.client.AddDomainPolicy(ctx, client.DomainPolicyRequest{
+			Domain: plan.Domain.Value,
+			List:   plan.Type.Value,
+			From:   plan.From.Value,
+		})
+	} else {
+		id, err = r.p.client.AddMailboxPolicy(ctx, client.MailboxPolicyRequest{
+			Username: plan.Mailbox.Value,
+			List:     plan.Type.Value,
+			From:     plan.From.Value,
+		})
+	}
+	if client.IsAlreadyExists(err) {
+		resp.Diagnostics.AddError("Spam Policy Already Exists", fmt.Sprintf("The sender is already listed in mailcow, import the entry as <domain or mailbox>/<type>/<ID> instead: %s", err))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create spam policy, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = types.Int64{Value: id}
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceSpamPolicy) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state SpamPolicy
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var policies *[]client.SpamPolicyResponse
+	var err error
+	if !state.Domain.Null {
+		policies, err = r.p.client.GetDomainPolicies(ctx, state.Domain.Value, state.Type.Value)
+	} else {
+		policies, err = r.p.client.GetMailboxPolicies(ctx, state.Mailbox.Value, state.Type.Value)
+	}
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read spam policy, got error: %s", err))
+		return
+	}
+
+	// The entry is gone when it is no longer in the list of its domain or mailbox
+	var policy *client.SpamPolicyResponse
+	for i := range *policies {
+		if (*policies)[i].ID == state.ID.Value {
+			policy = &(*policies)[i]
+		}
+	}
+	if policy == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.From = types.String{Value: policy.From}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only stores the new timeouts, every other attribute forces a new entry.
+func (r resourceSpamPolicy) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan SpamPolicy
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceSpamPolicy) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state SpamPolicy
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	var err error
+	if !state.Domain.Null {
+		err = r.p.client.DeleteDomainPolicy(ctx, state.ID.Value)
+	} else {
+		err = r.p.client.DeleteMailboxPolicy(ctx, state.ID.Value)
+	}
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete spam policy, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts <domain or mailbox>/<type>/<ID>, as mailcow can only
+// list the entries of one domain or mailbox at a time.
+func (r resourceSpamPolicy) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[1] != "wl" && parts[1] != "bl") {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected <domain or mailbox>/<wl or bl>/<ID>, got: %s", req.ID))
+		return
+	}
+
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected a numeric spam policy ID, got: %s", parts[2]))
+		return
+	}
+
+	scope := "domain"
+	if strings.Contains(parts[0], "@") {
+		scope = "mailbox"
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName(scope), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("type"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), id)...)
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceSpamPolicy(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSpamPolicyDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceMailboxConfig(server, "User", "secret") + `
+resource "mailcow_spam_policy" "test" {
+  domain  = mailcow_domain.test.domain
+  mailbox = mailcow_mailbox.test.email
+  type    = "wl"
+  from    = "*@example.org"
+}
+`,
+				ExpectError: regexp.MustCompile(`Exactly one of domain and mailbox must be set`),
+			},
+			{
+				Config: testAccResourceSpamPolicyConfig(server, "*@spam.example", "*@example.org"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_spam_policy.domain", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_spam_policy.domain", "type", "bl"),
+					resource.TestCheckResourceAttr("mailcow_spam_policy.domain", "from", "*@spam.example"),
+					resource.TestCheckResourceAttr("mailcow_spam_policy.mailbox", "id", "2"),
+					resource.TestCheckResourceAttr("mailcow_spam_policy.mailbox", "mailbox", "user@mailcow.tld"),
+					testAccCheckSpamPolicyFrom(server, 2, "*@example.org"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_spam_policy.domain",
+				ImportState:             true,
+				ImportStateId:           "mailcow.tld/bl/1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				ResourceName:            "mailcow_spam_policy.mailbox",
+				ImportState:             true,
+				ImportStateId:           "user@mailcow.tld/wl/2",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				// Entries cannot be edited, so a new sender replaces the entry
+				Config: testAccResourceSpamPolicyConfig(server, "*@spam.example", "friend@example.org"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_spam_policy.mailbox", "id", "3"),
+					testAccCheckSpamPolicyFrom(server, 3, "friend@example.org"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveSpamPolicy(1) },
+				Config:    testAccResourceSpamPolicyConfig(server, "*@spam.example", "friend@example.org"),
+				Check:     testAccCheckSpamPolicyFrom(server, 4, "*@spam.example"),
+			},
+		},
+	})
+}
+
+func testAccResourceSpamPolicyConfig(server *mailcowtest.Server, domainFrom, mailboxFrom string) string {
+	return testAccResourceMailboxConfig(server, "User", "secret") + fmt.Sprintf(`
+resource "mailcow_spam_policy" "domain" {
+  domain = mailcow_domain.test.domain
+  type   = "bl"
+  from   = %q
+}
+
+resource "mailcow_spam_policy" "mailbox" {
+  mailbox = mailcow_mailbox.test.email
+  type    = "wl"
+  from    = %q
+}
+`, domainFrom, mailboxFrom)
+}
+
+func testAccCheckSpamPolicyFrom(server *mailcowtest.Server, id int64, from string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		policy := server.SpamPolicy(id)
+		if policy == nil {
+			return fmt.Errorf("spam policy %d does not exist", id)
+		}
+		if policy.From != from {
+			return fmt.Errorf("spam policy %d is for %s, want %s", id, policy.From, from)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSpamPolicyDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_spam_policy" {
+				continue
+			}
+
+			id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			if server.SpamPolicy(id) != nil {
+				return fmt.Errorf("spam policy %d still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,350 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kraihn/terraform-provider-mailcow/internal/client"
+	"github.com/kraihn/terraform-provider-mailcow/internal/plan_modifiers"
+	"github.com/kraihn/terraform-provider-mailcow/internal/validators"
+	"strconv"
+)
+
+type resourceSyncJobType struct{}
+
+func (r resourceSyncJobType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "An imapsync job copying mail from a remote IMAP account into a mailbox.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.Int64Type,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"mailbox": {
+				Type:        types.StringType,
+				Description: "The email address of the mailbox mail is copied into.",
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"host": {
+				Type:        types.StringType,
+				Description: "The remote IMAP server.",
+				Required:    true,
+			},
+			"port": {
+				Type:     types.Int64Type,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultInt64(993),
+				},
+			},
+			"encryption": {
+				Type:        types.StringType,
+				Description: "One of SSL, TLS (STARTTLS) or PLAIN. Defaults to SSL.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultString("SSL"),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					validators.StringOneOfValidator{Values: []string{"SSL", "TLS", "PLAIN"}},
+				},
+			},
+			"user": {
+				Type:        types.StringType,
+				Description: "The login of the remote IMAP account.",
+				Required:    true,
+			},
+			"password": {
+				Type:      types.StringType,
+				Required:  true,
+				Sensitive: true,
+			},
+			"interval": {
+				Type:        types.Int64Type,
+				Description: "Minutes between runs. Defaults to 20.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultInt64(20),
+				},
+			},
+			"delete_from_source": {
+				Type:        types.BoolType,
+				Description: "Delete messages from the remote account once they are copied.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultBool(false),
+				},
+			},
+			"exclude": {
+				Type:        types.StringType,
+				Description: "A regular expression of remote folders to skip. Defaults to \"(?i)spam|(?i)junk\".",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultString("(?i)spam|(?i)junk"),
+				},
+			},
+			"subfolder": {
+				Type:        types.StringType,
+				Description: "The folder of the mailbox to copy into, empty for the top level. Defaults to \"External\".",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultString("External"),
+				},
+			},
+			"custom_params": {
+				Type:        types.StringType,
+				Description: "Additional imapsync command line parameters.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultString(""),
+				},
+			},
+			"active": {
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					plan_modifiers.DefaultBool(true),
+				},
+			},
+			"is_running": {
+				Type:        types.BoolType,
+				Description: "Whether the job is running right now.",
+				Computed:    true,
+			},
+			"last_run": {
+				Type:        types.StringType,
+				Description: "When the job last ran, empty if it never did.",
+				Computed:    true,
+			},
+			"last_run_success": {
+				Type:        types.BoolType,
+				Description: "Whether the last run succeeded, null if the job never ran.",
+				Computed:    true,
+			},
+			"exit_status": {
+				Type:        types.StringType,
+				Description: "The imapsync exit status of the last run, such as EX_OK.",
+				Computed:    true,
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}, nil
+}
+
+func (r resourceSyncJobType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceSyncJob{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceSyncJob struct {
+	p provider
+}
+
+func (r resourceSyncJob) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan SyncJob
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	syncJob := plan.request()
+	syncJob.Username = plan.Mailbox.Value
+	syncJob.Password = plan.Password.Value
+
+	id, err := r.p.client.AddSyncJob(ctx, syncJob)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create sync job, got error: %s", err))
+		return
+	}
+
+	created, err := r.p.client.GetSyncJob(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sync job, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = types.Int64{Value: id}
+	result.updateStatus(created)
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceSyncJob) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state SyncJob
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	syncJob, err := r.p.client.GetSyncJob(ctx, state.ID.Value)
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sync job, got error: %s", err))
+		return
+	}
+
+	state.Mailbox = types.String{Value: syncJob.Username}
+	state.Host = types.String{Value: syncJob.Host}
+	state.Port = types.Int64{Value: syncJob.Port}
+	state.Encryption = types.String{Value: syncJob.Encryption}
+	state.User = types.String{Value: syncJob.User}
+	state.Interval = types.Int64{Value: syncJob.Interval}
+	state.DeleteSource = types.Bool{Value: syncJob.DeleteSource == 1}
+	state.Exclude = types.String{Value: syncJob.Exclude}
+	state.Subfolder = types.String{Value: syncJob.Subfolder}
+	state.CustomParams = types.String{Value: syncJob.CustomParams}
+	state.Active = types.Bool{Value: syncJob.Active == 1}
+	state.updateStatus(syncJob)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceSyncJob) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan SyncJob
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state SyncJob
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	syncJob := plan.request()
+	if !plan.Password.Equal(state.Password) {
+		syncJob.Password = plan.Password.Value
+	}
+
+	err := r.p.client.EditSyncJob(ctx, state.ID.Value, syncJob)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update sync job, got error: %s", err))
+		return
+	}
+
+	updated, err := r.p.client.GetSyncJob(ctx, state.ID.Value)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sync job, got error: %s", err))
+		return
+	}
+
+	result := plan
+	result.ID = state.ID
+	result.updateStatus(updated)
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r resourceSyncJob) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state SyncJob
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	err := r.p.client.DeleteSyncJob(ctx, state.ID.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete sync job, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r resourceSyncJob) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	importInt64ID(ctx, "sync job", req, resp)
+}
+
+// request returns the settings of s shared by add and edit requests.
+func (s SyncJob) request() client.SyncJobRequest {
+	return client.SyncJobRequest{
+		Host:         s.Host.Value,
+		Port:         strconv.FormatInt(s.Port.Value, 10),
+		Encryption:   s.Encryption.Value,
+		User:         s.User.Value,
+		Interval:     strconv.FormatInt(s.Interval.Value, 10),
+		DeleteSource: boolToString(s.DeleteSource.Value),
+		Exclude:      s.Exclude.Value,
+		Subfolder:    s.Subfolder.Value,
+		CustomParams: s.CustomParams.Value,
+		Active:       boolToString(s.Active.Value),
+	}
+}
+
+// updateStatus sets the computed last run attributes of s.
+func (s *SyncJob) updateStatus(syncJob *client.SyncJobResponse) {
+	s.IsRunning = types.Bool{Value: syncJob.IsRunning == 1}
+	s.LastRun = types.String{Value: syncJob.LastRun}
+	s.ExitStatus = types.String{Value: syncJob.ExitStatus}
+	s.LastSuccess = types.Bool{Null: syncJob.Success == nil}
+	if syncJob.Success != nil {
+		s.LastSuccess.Value = *syncJob.Success == 1
+	}
+}
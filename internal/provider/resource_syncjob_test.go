@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceSyncJob(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSyncJobDestroy(server, 1, 2),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceSyncJobConfig(server, `encryption = "SSH"`),
+				ExpectError: regexp.MustCompile(`got: "SSH"`),
+			},
+			{
+				Config: testAccResourceSyncJobConfig(server, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "mailbox", "user@mailcow.tld"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "port", "993"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "encryption", "SSL"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "interval", "20"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "subfolder", "External"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "exclude", "(?i)spam|(?i)junk"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "is_running", "false"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "last_run", ""),
+					resource.TestCheckNoResourceAttr("mailcow_syncjob.test", "last_run_success"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_syncjob.test",
+				ImportState:             true,
+				ImportStateId:           "1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "timeouts"},
+			},
+			{
+				// imapsync ran in the meantime, which only changes the status
+				PreConfig: func() { server.FinishSyncJob(1, "2022-08-01 12:00:00", "EX_OK") },
+				Config: testAccResourceSyncJobConfig(server, `
+  interval           = 60
+  delete_from_source = true
+  subfolder          = ""
+  custom_params      = "--nofoldersizes"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "interval", "60"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "delete_from_source", "true"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "last_run", "2022-08-01 12:00:00"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "last_run_success", "true"),
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "exit_status", "EX_OK"),
+					testAccCheckSyncJobSubfolder(server, 1, ""),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveSyncJob(1) },
+				Config:    testAccResourceSyncJobConfig(server, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_syncjob.test", "id", "2"),
+					testAccCheckSyncJobSubfolder(server, 2, "External"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSyncJobConfig(server *mailcowtest.Server, extra string) string {
+	return testAccResourceMailboxConfig(server, "User", "secret") + fmt.Sprintf(`
+resource "mailcow_syncjob" "test" {
+  mailbox  = mailcow_mailbox.test.email
+  host     = "imap.example.com"
+  user     = "legacy-user"
+  password = "legacy-secret"
+  %s
+}
+`, extra)
+}
+
+func testAccCheckSyncJobSubfolder(server *mailcowtest.Server, id int64, subfolder string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		syncJob := server.SyncJob(id)
+		if syncJob == nil {
+			return fmt.Errorf("sync job %d does not exist", id)
+		}
+		if syncJob.Subfolder != subfolder {
+			return fmt.Errorf("sync job %d has subfolder %q, want %q", id, syncJob.Subfolder, subfolder)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSyncJobDestroy(server *mailcowtest.Server, ids ...int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, id := range ids {
+			if server.SyncJob(id) != nil {
+				return fmt.Errorf("sync job %d still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceTLSPolicy(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTLSPolicyDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTLSPolicyConfig(server, "required", ""),
+				ExpectError: regexp.MustCompile(`got: "required"`),
+			},
+			{
+				Config: testAccResourceTLSPolicyConfig(server, "encrypt", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_tls_policy.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_tls_policy.test", "destination", "partner.example"),
+					resource.TestCheckResourceAttr("mailcow_tls_policy.test", "policy", "encrypt"),
+					resource.TestCheckResourceAttr("mailcow_tls_policy.test", "parameters", ""),
+					resource.TestCheckResourceAttr("mailcow_tls_policy.test", "active", "true"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_tls_policy.test",
+				ImportState:             true,
+				ImportStateId:           "1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				Config: testAccResourceTLSPolicyConfig(server, "secure", "match=.partner.example"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_tls_policy.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_tls_policy.test", "parameters", "match=.partner.example"),
+					testAccCheckTLSPolicyLevel(server, 1, "secure"),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveTLSPolicy(1) },
+				Config:    testAccResourceTLSPolicyConfig(server, "secure", "match=.partner.example"),
+				Check:     testAccCheckTLSPolicyLevel(server, 2, "secure"),
+			},
+		},
+	})
+}
+
+func testAccResourceTLSPolicyConfig(server *mailcowtest.Server, policy string, parameters string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mailcow_tls_policy" "test" {
+  destination = "partner.example"
+  policy      = %q
+  parameters  = %q
+}
+`, policy, parameters)
+}
+
+func testAccCheckTLSPolicyLevel(server *mailcowtest.Server, id int64, policy string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tlsPolicy := server.TLSPolicy(id)
+		if tlsPolicy == nil {
+			return fmt.Errorf("TLS policy %d does not exist", id)
+		}
+		if tlsPolicy.Policy != policy {
+			return fmt.Errorf("TLS policy %d has policy %s, want %s", id, tlsPolicy.Policy, policy)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTLSPolicyDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_tls_policy" {
+				continue
+			}
+
+			id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			if server.TLSPolicy(id) != nil {
+				return fmt.Errorf("TLS policy %d still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kraihn/terraform-provider-mailcow/internal/mailcowtest"
+)
+
+func TestAccResourceTransport(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTransportDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTransportConfig(server, "[smtp.example.com]:587", false, "secret"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_transport.test", "id", "1"),
+					resource.TestCheckResourceAttr("mailcow_transport.test", "destination", "example.org"),
+					resource.TestCheckResourceAttr("mailcow_transport.test", "nexthop", "[smtp.example.com]:587"),
+					resource.TestCheckResourceAttr("mailcow_transport.test", "username", "relay"),
+					resource.TestCheckResourceAttr("mailcow_transport.test", "is_mx_based", "false"),
+					resource.TestCheckResourceAttr("mailcow_transport.test", "active", "true"),
+				),
+			},
+			{
+				ResourceName:            "mailcow_transport.test",
+				ImportState:             true,
+				ImportStateId:           "1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "timeouts"},
+			},
+			{
+				Config: testAccResourceTransportConfig(server, "[smtp.example.net]:25", true, "secret"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_transport.test", "nexthop", "[smtp.example.net]:25"),
+					resource.TestCheckResourceAttr("mailcow_transport.test", "is_mx_based", "true"),
+					testAccCheckTransportNexthop(server, 1, "[smtp.example.net]:25"),
+				),
+			},
+			{
+				// Removing the credentials clears them in mailcow too
+				Config: testAccResourceTransportConfig(server, "[smtp.example.net]:25", true, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mailcow_transport.test", "username", ""),
+					resource.TestCheckNoResourceAttr("mailcow_transport.test", "password"),
+					testAccCheckTransportPassword(server, 1, ""),
+				),
+			},
+			{
+				// Deleted in the mailcow UI, so Terraform plans a create
+				PreConfig: func() { server.RemoveTransport(1) },
+				Config:    testAccResourceTransportConfig(server, "[smtp.example.net]:25", true, "secret"),
+				Check:     testAccCheckTransportNexthop(server, 2, "[smtp.example.net]:25"),
+			},
+		},
+	})
+}
+
+func testAccResourceTransportConfig(server *mailcowtest.Server, nexthop string, isMXBased bool, password string) string {
+	credentials := ""
+	if password != "" {
+		credentials = fmt.Sprintf("username    = \"relay\"\n  password    = %q", password)
+	}
+
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mailcow_transport" "test" {
+  destination = "example.org"
+  nexthop     = %q
+  is_mx_based = %t
+  %s
+}
+`, nexthop, isMXBased, credentials)
+}
+
+func testAccCheckTransportPassword(server *mailcowtest.Server, id int64, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		transport := server.Transport(id)
+		if transport == nil {
+			return fmt.Errorf("transport %d does not exist", id)
+		}
+		if transport.Password != password {
+			return fmt.Errorf("transport %d has an outdated password", id)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTransportNexthop(server *mailcowtest.Server, id int64, nexthop string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		transport := server.Transport(id)
+		if transport == nil {
+			return fmt.Errorf("transport %d does not exist", id)
+		}
+		if transport.Nexthop != nexthop {
+			return fmt.Errorf("transport %d has nexthop %s, want %s", id, transport.Nexthop, nexthop)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTransportDestroy(server *mailcowtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "mailcow_transport" {
+				continue
+			}
+
+			id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			if server.Transport(id) != nil {
+				return fmt.Errorf("transport %d still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
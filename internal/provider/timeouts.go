@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"time"
+)
+
+const defaultTimeout = 5 * time.Minute
+
+type Timeouts struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsAttribute is the optional timeouts block shared by all resources.
+func timeoutsAttribute() tfsdk.Attribute {
+	description := func(operation string) string {
+		return fmt.Sprintf("How long to wait for the %s to finish, as a duration like \"30s\" or \"10m\". Defaults to %s.", operation, defaultTimeout)
+	}
+
+	return tfsdk.Attribute{
+		Optional: true,
+		Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+			"create": {
+				Type:        types.StringType,
+				Description: description("create"),
+				Optional:    true,
+			},
+			"update": {
+				Type:        types.StringType,
+				Description: description("update"),
+				Optional:    true,
+			},
+			"delete": {
+				Type:        types.StringType,
+				Description: description("delete"),
+				Optional:    true,
+			},
+		}),
+	}
+}
+
+// withTimeout derives a context from ctx that expires after the configured
+// timeout of operation, or defaultTimeout when none is set.
+func withTimeout(ctx context.Context, timeouts *Timeouts, operation string) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var value types.String
+	if timeouts != nil {
+		switch operation {
+		case "create":
+			value = timeouts.Create
+		case "update":
+			value = timeouts.Update
+		case "delete":
+			value = timeouts.Delete
+		}
+	}
+
+	timeout := defaultTimeout
+	if !value.Null && !value.Unknown && value.Value != "" {
+		parsed, err := time.ParseDuration(value.Value)
+		if err != nil || parsed <= 0 {
+			diags.AddAttributeError(
+				tftypes.NewAttributePath().WithAttributeName("timeouts").WithAttributeName(operation),
+				"Invalid Timeout",
+				fmt.Sprintf("Unable to parse %q as a positive duration like \"30s\" or \"10m\".", value.Value),
+			)
+			return ctx, func() {}, diags
+		}
+		timeout = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	return ctx, cancel, diags
+}
@@ -0,0 +1,56 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net"
+)
+
+// CIDRValidator checks that a string, or every element of a list or set of
+// strings, is an IPv4 or IPv6 network in CIDR notation.
+type CIDRValidator struct {
+}
+
+func (v CIDRValidator) Description(ctx context.Context) string {
+	return "value must be a network in CIDR notation"
+}
+
+func (v CIDRValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a network in CIDR notation, such as `192.0.2.0/24`"
+}
+
+func (v CIDRValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var elems []attr.Value
+	switch value := req.AttributeConfig.(type) {
+	case types.List:
+		elems = value.Elems
+	case types.Set:
+		elems = value.Elems
+	default:
+		elems = []attr.Value{req.AttributeConfig}
+	}
+
+	for _, elem := range elems {
+		var value types.String
+		diags := tfsdk.ValueAs(ctx, elem, &value)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		if value.Unknown || value.Null {
+			continue
+		}
+
+		if _, _, err := net.ParseCIDR(value.Value); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath,
+				"Invalid Network",
+				fmt.Sprintf("Value must be a network in CIDR notation, such as 192.0.2.0/24, got: %q.", value.Value),
+			)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Int64AtLeastValidator struct {
+	Min int64
+}
+
+func (v Int64AtLeastValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.Min)
+}
+
+func (v Int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least `%d`", v.Min)
+}
+
+func (v Int64AtLeastValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.Int64
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &value)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if value.Unknown || value.Null || value.Value >= v.Min {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.AttributePath,
+		"Invalid Value",
+		fmt.Sprintf("Value must be at least %d, got: %d.", v.Min, value.Value),
+	)
+}
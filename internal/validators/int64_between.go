@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Int64BetweenValidator struct {
+	Min int64
+	Max int64
+}
+
+func (v Int64BetweenValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be between %d and %d", v.Min, v.Max)
+}
+
+func (v Int64BetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be between `%d` and `%d`", v.Min, v.Max)
+}
+
+func (v Int64BetweenValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.Int64
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &value)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if value.Unknown || value.Null || (value.Value >= v.Min && value.Value <= v.Max) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.AttributePath,
+		"Invalid Value",
+		fmt.Sprintf("Value must be between %d and %d, got: %d.", v.Min, v.Max, value.Value),
+	)
+}
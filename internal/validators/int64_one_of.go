@@ -0,0 +1,55 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+type Int64OneOfValidator struct {
+	Values []int64
+}
+
+func (v Int64OneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", v.list())
+}
+
+func (v Int64OneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", v.list())
+}
+
+func (v Int64OneOfValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.Int64
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &value)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if value.Unknown || value.Null {
+		return
+	}
+
+	for _, allowed := range v.Values {
+		if value.Value == allowed {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.AttributePath,
+		"Invalid Value",
+		fmt.Sprintf("Value must be one of %s, got: %d.", v.list(), value.Value),
+	)
+}
+
+func (v Int64OneOfValidator) list() string {
+	var values []string
+	for _, value := range v.Values {
+		values = append(values, fmt.Sprint(value))
+	}
+
+	return strings.Join(values, ", ")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// ListNotEmptyValidator checks that a list or set has at least one element.
 type ListNotEmptyValidator struct {
 }
 
@@ -19,19 +20,27 @@ func (v ListNotEmptyValidator) MarkdownDescription(ctx context.Context) string {
 }
 
 func (v ListNotEmptyValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
-	var list types.List
-	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &list)
-	resp.Diagnostics.Append(diags...)
-	if diags.HasError() {
-		return
-	}
-
-	if list.Unknown || list.Null {
+	var listLength int
+	switch value := req.AttributeConfig.(type) {
+	case types.List:
+		if value.Unknown || value.Null {
+			return
+		}
+		listLength = len(value.Elems)
+	case types.Set:
+		if value.Unknown || value.Null {
+			return
+		}
+		listLength = len(value.Elems)
+	default:
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Value Type",
+			fmt.Sprintf("Expected a list or set, got: %T.", req.AttributeConfig),
+		)
 		return
 	}
 
-	listLength := len(list.Elems)
-
 	if listLength < 1 {
 		resp.Diagnostics.AddAttributeError(
 			req.AttributePath,
@@ -0,0 +1,549 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+// SieveValidator checks that a string is a syntactically valid Sieve script
+// (RFC 5228), so a broken filter fails at plan time rather than in mailcow.
+// Besides the core language it knows the commands and tests of the common
+// extensions and checks that they are required before they are used.
+type SieveValidator struct{}
+
+func (v SieveValidator) Description(ctx context.Context) string {
+	return "value must be a valid Sieve script"
+}
+
+func (v SieveValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a valid [Sieve](https://www.rfc-editor.org/rfc/rfc5228) script"
+}
+
+func (v SieveValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &value)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if value.Unknown || value.Null {
+		return
+	}
+
+	if err := parseSieve(value.Value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Sieve Script",
+			fmt.Sprintf("The script is not valid Sieve: %s.", err),
+		)
+	}
+}
+
+// sieveExtensions maps the commands and tests of common extensions to the
+// capability a script must require before using them.
+var sieveExtensions = map[string]string{
+	"addflag":                  "imap4flags",
+	"addheader":                "editheader",
+	"body":                     "body",
+	"currentdate":              "date",
+	"date":                     "date",
+	"deleteheader":             "editheader",
+	"duplicate":                "duplicate",
+	"envelope":                 "envelope",
+	"ereject":                  "ereject",
+	"error":                    "ihave",
+	"fileinto":                 "fileinto",
+	"global":                   "include",
+	"hasflag":                  "imap4flags",
+	"ihave":                    "ihave",
+	"include":                  "include",
+	"mailboxexists":            "mailbox",
+	"notify":                   "enotify",
+	"notify_method_capability": "enotify",
+	"reject":                   "reject",
+	"removeflag":               "imap4flags",
+	"return":                   "include",
+	"set":                      "variables",
+	"setflag":                  "imap4flags",
+	"spamtest":                 "spamtest",
+	"string":                   "variables",
+	"vacation":                 "vacation",
+	"valid_notify_method":      "enotify",
+	"virustest":                "virustest",
+}
+
+// sieveCommands and sieveTests hold the commands and tests of RFC 5228 and of
+// the extensions above, so a misspelled name is caught like a syntax error.
+var (
+	sieveCommands = map[string]bool{
+		"addflag":      true,
+		"addheader":    true,
+		"deleteheader": true,
+		"discard":      true,
+		"else":         true,
+		"elsif":        true,
+		"ereject":      true,
+		"error":        true,
+		"fileinto":     true,
+		"global":       true,
+		"if":           true,
+		"include":      true,
+		"keep":         true,
+		"notify":       true,
+		"redirect":     true,
+		"reject":       true,
+		"removeflag":   true,
+		"require":      true,
+		"return":       true,
+		"set":          true,
+		"setflag":      true,
+		"stop":         true,
+		"vacation":     true,
+	}
+	sieveTests = map[string]bool{
+		"address":                  true,
+		"allof":                    true,
+		"anyof":                    true,
+		"body":                     true,
+		"currentdate":              true,
+		"date":                     true,
+		"duplicate":                true,
+		"envelope":                 true,
+		"exists":                   true,
+		"false":                    true,
+		"hasflag":                  true,
+		"header":                   true,
+		"ihave":                    true,
+		"mailboxexists":            true,
+		"not":                      true,
+		"notify_method_capability": true,
+		"size":                     true,
+		"spamtest":                 true,
+		"string":                   true,
+		"true":                     true,
+		"valid_notify_method":      true,
+		"virustest":                true,
+	}
+)
+
+type sieveTokenKind int
+
+const (
+	sieveEOF sieveTokenKind = iota
+	sieveIdentifier
+	sieveTag
+	sieveNumber
+	sieveString
+	sievePunctuation
+)
+
+type sieveToken struct {
+	kind  sieveTokenKind
+	value string
+	line  int
+}
+
+func (t sieveToken) String() string {
+	switch t.kind {
+	case sieveEOF:
+		return "end of script"
+	case sieveString:
+		return "string"
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+func (t sieveToken) is(punctuation string) bool {
+	return t.kind == sievePunctuation && t.value == punctuation
+}
+
+type sieveParser struct {
+	src     string
+	pos     int
+	line    int
+	peeked  *sieveToken
+	require map[string]bool
+}
+
+func parseSieve(src string) error {
+	p := &sieveParser{src: src, line: 1, require: map[string]bool{}}
+
+	return p.parseCommands(false)
+}
+
+// parseCommands parses commands up to the end of the script, or up to the
+// closing brace of a block.
+func (p *sieveParser) parseCommands(inBlock bool) error {
+	previous := ""
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case tok.kind == sieveEOF && inBlock:
+			return fmt.Errorf("line %d: missing } at end of script", tok.line)
+		case tok.kind == sieveEOF:
+			return nil
+		case tok.is("}") && inBlock:
+			return nil
+		case tok.kind != sieveIdentifier:
+			return fmt.Errorf("line %d: expected a command, got %s", tok.line, tok)
+		}
+
+		name := strings.ToLower(tok.value)
+		if (name == "elsif" || name == "else") && previous != "if" && previous != "elsif" {
+			return fmt.Errorf("line %d: %s without a preceding if", tok.line, name)
+		}
+		if name == "require" && (inBlock || (previous != "" && previous != "require")) {
+			return fmt.Errorf("line %d: require must come before any other command", tok.line)
+		}
+
+		if err := p.parseCommand(tok); err != nil {
+			return err
+		}
+		previous = name
+	}
+}
+
+func (p *sieveParser) parseCommand(command sieveToken) error {
+	name := strings.ToLower(command.value)
+	if !sieveCommands[name] {
+		return fmt.Errorf("line %d: unknown command %q", command.line, command.value)
+	}
+	if err := p.checkRequired(command); err != nil {
+		return err
+	}
+
+	values, err := p.parseArguments()
+	if err != nil {
+		return err
+	}
+
+	if name == "require" {
+		for _, capability := range values {
+			p.require[capability] = true
+		}
+	}
+
+	// Only if and elsif take a test, so for any other command an identifier
+	// here is the next command after a missing semicolon
+	if name == "if" || name == "elsif" {
+		tok, err := p.peek()
+		if err != nil {
+			return err
+		}
+		if tok.kind != sieveIdentifier && !tok.is("(") {
+			return fmt.Errorf("line %d: %s requires a test, got %s", tok.line, name, tok)
+		}
+		if err := p.parseTests(); err != nil {
+			return err
+		}
+	}
+
+	tok, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	if (name == "if" || name == "elsif" || name == "else") && !tok.is("{") {
+		return fmt.Errorf("line %d: expected { after %s, got %s", tok.line, name, tok)
+	}
+
+	switch {
+	case tok.is(";"):
+		return nil
+	case tok.is("{"):
+		return p.parseCommands(true)
+	default:
+		return fmt.Errorf("line %d: expected ; after %s, got %s", tok.line, name, tok)
+	}
+}
+
+// parseTests parses a single test or a parenthesized test list.
+func (p *sieveParser) parseTests() error {
+	tok, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	if tok.kind == sieveIdentifier {
+		if !sieveTests[strings.ToLower(tok.value)] {
+			return fmt.Errorf("line %d: unknown test %q", tok.line, tok.value)
+		}
+		if err := p.checkRequired(tok); err != nil {
+			return err
+		}
+		if _, err := p.parseArguments(); err != nil {
+			return err
+		}
+
+		// not, anyof and allof wrap a test or a test list
+		switch strings.ToLower(tok.value) {
+		case "not", "anyof", "allof":
+			return p.parseTests()
+		}
+
+		return nil
+	}
+
+	if !tok.is("(") {
+		return fmt.Errorf("line %d: expected a test, got %s", tok.line, tok)
+	}
+
+	for {
+		if err := p.parseTests(); err != nil {
+			return err
+		}
+
+		tok, err := p.next()
+		if err != nil {
+			return err
+		}
+		if tok.is(")") {
+			return nil
+		}
+		if !tok.is(",") {
+			return fmt.Errorf("line %d: expected , or ) in test list, got %s", tok.line, tok)
+		}
+	}
+}
+
+// parseArguments parses tags, numbers, strings and string lists, returning the
+// strings so require can record its capabilities.
+func (p *sieveParser) parseArguments() ([]string, error) {
+	var values []string
+	for {
+		tok, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case tok.kind == sieveTag, tok.kind == sieveNumber:
+			p.peeked = nil
+		case tok.kind == sieveString:
+			p.peeked = nil
+			values = append(values, tok.value)
+		case tok.is("["):
+			p.peeked = nil
+			list, err := p.parseStringList()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, list...)
+		default:
+			return values, nil
+		}
+	}
+}
+
+func (p *sieveParser) parseStringList() ([]string, error) {
+	var values []string
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok.kind != sieveString {
+			return nil, fmt.Errorf("line %d: expected a string in string list, got %s", tok.line, tok)
+		}
+		values = append(values, tok.value)
+
+		tok, err = p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok.is("]") {
+			return values, nil
+		}
+		if !tok.is(",") {
+			return nil, fmt.Errorf("line %d: expected , or ] in string list, got %s", tok.line, tok)
+		}
+	}
+}
+
+func (p *sieveParser) checkRequired(tok sieveToken) error {
+	name := strings.ToLower(tok.value)
+	if capability, ok := sieveExtensions[name]; ok && !p.require[capability] {
+		return fmt.Errorf("line %d: %s needs require %q", tok.line, name, capability)
+	}
+
+	return nil
+}
+
+func (p *sieveParser) peek() (sieveToken, error) {
+	if p.peeked == nil {
+		tok, err := p.lex()
+		if err != nil {
+			return sieveToken{}, err
+		}
+		p.peeked = &tok
+	}
+
+	return *p.peeked, nil
+}
+
+func (p *sieveParser) next() (sieveToken, error) {
+	tok, err := p.peek()
+	p.peeked = nil
+
+	return tok, err
+}
+
+func (p *sieveParser) lex() (sieveToken, error) {
+	if err := p.skipWhitespace(); err != nil {
+		return sieveToken{}, err
+	}
+
+	line := p.line
+	if p.pos >= len(p.src) {
+		return sieveToken{kind: sieveEOF, line: line}, nil
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case strings.IndexByte(";{}()[],", c) >= 0:
+		p.pos++
+		return sieveToken{kind: sievePunctuation, value: string(c), line: line}, nil
+	case c == '"':
+		return p.lexQuotedString()
+	case c == ':':
+		p.pos++
+		name := p.lexWord()
+		if name == "" || !isSieveIdentifierStart(name[0]) {
+			return sieveToken{}, fmt.Errorf("line %d: expected a tag name after :", line)
+		}
+		return sieveToken{kind: sieveTag, value: ":" + name, line: line}, nil
+	case c >= '0' && c <= '9':
+		word := p.lexWord()
+		digits := strings.TrimRight(word, "KMGkmg")
+		if len(word)-len(digits) > 1 || strings.TrimLeft(digits, "0123456789") != "" {
+			return sieveToken{}, fmt.Errorf("line %d: invalid number %q", line, word)
+		}
+		return sieveToken{kind: sieveNumber, value: word, line: line}, nil
+	case isSieveIdentifierStart(c):
+		word := p.lexWord()
+		if strings.EqualFold(word, "text") && p.pos < len(p.src) && p.src[p.pos] == ':' {
+			p.pos++
+			return p.lexMultiLineString(line)
+		}
+		return sieveToken{kind: sieveIdentifier, value: word, line: line}, nil
+	default:
+		return sieveToken{}, fmt.Errorf("line %d: unexpected character %q", line, c)
+	}
+}
+
+func (p *sieveParser) skipWhitespace() error {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == '\n':
+			p.line++
+			p.pos++
+		case c == ' ', c == '\t', c == '\r':
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case strings.HasPrefix(p.src[p.pos:], "/*"):
+			line := p.line
+			end := strings.Index(p.src[p.pos+2:], "*/")
+			if end < 0 {
+				return fmt.Errorf("line %d: unterminated comment", line)
+			}
+			comment := p.src[p.pos : p.pos+2+end+2]
+			p.line += strings.Count(comment, "\n")
+			p.pos += len(comment)
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (p *sieveParser) lexWord() string {
+	start := p.pos
+	for p.pos < len(p.src) && (isSieveIdentifierStart(p.src[p.pos]) || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+		p.pos++
+	}
+
+	return p.src[start:p.pos]
+}
+
+func (p *sieveParser) lexQuotedString() (sieveToken, error) {
+	line := p.line
+	var value strings.Builder
+
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		c := p.src[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			return sieveToken{kind: sieveString, value: value.String(), line: line}, nil
+		case c == '\\' && p.pos+1 < len(p.src):
+			p.pos++
+			c = p.src[p.pos]
+		}
+		if c == '\n' {
+			p.line++
+		}
+		value.WriteByte(c)
+	}
+
+	return sieveToken{}, fmt.Errorf("line %d: unterminated string", line)
+}
+
+// lexMultiLineString reads the lines after "text:" up to a line holding only
+// a dot.
+func (p *sieveParser) lexMultiLineString(line int) (sieveToken, error) {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+	if p.pos < len(p.src) && p.src[p.pos] == '#' {
+		for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+			p.pos++
+		}
+	}
+	if p.pos < len(p.src) && p.src[p.pos] == '\r' {
+		p.pos++
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != '\n' {
+		return sieveToken{}, fmt.Errorf("line %d: expected a line break after text:", line)
+	}
+
+	var lines []string
+	for p.pos < len(p.src) {
+		p.pos++
+		p.line++
+
+		end := strings.IndexByte(p.src[p.pos:], '\n')
+		if end < 0 {
+			end = len(p.src) - p.pos
+		}
+		text := strings.TrimSuffix(p.src[p.pos:p.pos+end], "\r")
+		p.pos += end
+
+		if text == "." {
+			if p.pos < len(p.src) {
+				p.pos++
+				p.line++
+			}
+			return sieveToken{kind: sieveString, value: strings.Join(lines, "\n"), line: line}, nil
+		}
+		lines = append(lines, strings.TrimPrefix(text, "."))
+	}
+
+	return sieveToken{}, fmt.Errorf("line %d: multi-line string is not terminated by a line with a single dot", line)
+}
+
+func isSieveIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,308 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSieve(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		// err is a substring of the expected error, empty for a valid script
+		err string
+	}{
+		{
+			name:   "empty",
+			script: "",
+		},
+		{
+			name:   "comments only",
+			script: "# nothing to do\n/* still\nnothing */\n",
+		},
+		{
+			name: "fileinto with require",
+			script: `require "fileinto";
+if header :contains "subject" "[SPAM]" {
+  fileinto "Junk";
+  stop;
+}
+`,
+		},
+		{
+			name: "require string list and several extensions",
+			script: `require ["fileinto", "imap4flags", "envelope"];
+if envelope :domain :is "from" "example.org" {
+  addflag "\\Seen";
+  fileinto "Example";
+}
+`,
+		},
+		{
+			name: "bracketed and hash comments between tokens",
+			script: `require /* inline */ "fileinto"; # trailing
+if /* before test */ exists "x-spam" # after test
+{
+  fileinto "Junk";
+}
+`,
+		},
+		{
+			name: "comment spanning lines keeps line numbers",
+			script: `/* first
+second
+third */
+if true { keep }
+`,
+			err: "line 4: expected ; after keep",
+		},
+		{
+			name: "escaped quotes in string",
+			script: `if header :is "subject" "say \"hi\"" { discard; }
+`,
+		},
+		{
+			name: "multi-line string with dot-stuffing",
+			script: `require "vacation";
+vacation :days 7 :subject "Away" text:
+I am away until Monday.
+..a line starting with a dot
+.
+;
+keep;
+`,
+		},
+		{
+			name: "multi-line string with comment after text:",
+			script: `require "vacation";
+vacation text: # the reply
+Away.
+.
+;
+`,
+		},
+		{
+			name: "unterminated multi-line string",
+			script: `require "vacation";
+vacation text:
+Away.
+`,
+			err: "line 2: multi-line string is not terminated",
+		},
+		{
+			name:   "text: followed by content on the same line",
+			script: "require \"vacation\";\nvacation text: Away.\n.\n;\n",
+			err:    "line 2: expected a line break after text:",
+		},
+		{
+			name: "anyof, allof and not test lists",
+			script: `if anyof (header :contains "from" "boss", allof (size :over 100K, not exists "x-keep")) {
+  discard;
+}
+`,
+		},
+		{
+			name: "nested not",
+			script: `if not not true { keep; }
+`,
+		},
+		{
+			name:   "unterminated test list",
+			script: `if anyof (true, false { keep; }`,
+			err:    "expected , or ) in test list",
+		},
+		{
+			name:   "empty test list",
+			script: `if anyof () { keep; }`,
+			err:    "expected a test",
+		},
+		{
+			name: "if, elsif and else",
+			script: `if header :is "to" "a@example.org" {
+  keep;
+} elsif header :is "to" "b@example.org" {
+  discard;
+} elsif size :under 1M {
+  keep;
+} else {
+  stop;
+}
+`,
+		},
+		{
+			name:   "else without if",
+			script: `else { keep; }`,
+			err:    "line 1: else without a preceding if",
+		},
+		{
+			name: "elsif after else",
+			script: `if true { keep; } else { discard; }
+elsif false { stop; }
+`,
+			err: "line 2: elsif without a preceding if",
+		},
+		{
+			name: "else after another command",
+			script: `if true { keep; }
+keep;
+else { discard; }
+`,
+			err: "line 3: else without a preceding if",
+		},
+		{
+			name:   "else with a test",
+			script: `if true { keep; } else true { discard; }`,
+			err:    "expected { after else",
+		},
+		{
+			name:   "if without a test",
+			script: `if { keep; }`,
+			err:    "if requires a test",
+		},
+		{
+			name:   "if without a block",
+			script: `if true keep;`,
+			err:    "expected { after if",
+		},
+		{
+			name: "require after another command",
+			script: `keep;
+require "fileinto";
+`,
+			err: "line 2: require must come before any other command",
+		},
+		{
+			name:   "require in a block",
+			script: `if true { require "fileinto"; }`,
+			err:    "require must come before any other command",
+		},
+		{
+			name:   "fileinto without require",
+			script: `fileinto "Junk";`,
+			err:    `fileinto needs require "fileinto"`,
+		},
+		{
+			name: "test of an extension without require",
+			script: `require "fileinto";
+if body :contains "lottery" { fileinto "Junk"; }
+`,
+			err: `line 2: body needs require "body"`,
+		},
+		{
+			name: "extension required under another name",
+			script: `require "fileinto";
+setflag "\\Flagged";
+`,
+			err: `setflag needs require "imap4flags"`,
+		},
+		{
+			name:   "extension names are case-insensitive",
+			script: `require "fileinto"; FileInto "Junk";`,
+		},
+		{
+			name:   "missing semicolon",
+			script: "keep\ndiscard;\n",
+			err:    "line 2: expected ; after keep",
+		},
+		{
+			name:   "missing closing brace",
+			script: "if true {\n  keep;\n",
+			err:    "line 3: missing } at end of script",
+		},
+		{
+			name:   "unterminated string",
+			script: `require "fileinto;`,
+			err:    "line 1: unterminated string",
+		},
+		{
+			name:   "unterminated comment",
+			script: "keep; /* forgot",
+			err:    "line 1: unterminated comment",
+		},
+		{
+			name:   "invalid number",
+			script: `if size :over 10X { discard; }`,
+			err:    `invalid number "10X"`,
+		},
+		{
+			name:   "number with two quantifiers",
+			script: `if size :over 1KM { discard; }`,
+			err:    `invalid number "1KM"`,
+		},
+		{
+			name:   "empty tag",
+			script: `if header : "a" "b" { keep; }`,
+			err:    "expected a tag name after :",
+		},
+		{
+			name:   "unterminated string list",
+			script: `require ["fileinto" "envelope"];`,
+			err:    "expected , or ] in string list",
+		},
+		{
+			name:   "number in string list",
+			script: `require ["fileinto", 1];`,
+			err:    "expected a string in string list",
+		},
+		{
+			name: "variables and date extensions",
+			script: `require ["variables", "date", "relational"];
+if currentdate :value "ge" "hour" "18" {
+  set "late" "1";
+}
+if string :is "${late}" "1" {
+  keep;
+}
+`,
+		},
+		{
+			name:   "misspelled command",
+			script: "require \"fileinto\";\nfileinot \"Junk\";",
+			err:    `line 2: unknown command "fileinot"`,
+		},
+		{
+			name:   "unknown command without require",
+			script: `forward "me@example.org";`,
+			err:    `unknown command "forward"`,
+		},
+		{
+			name:   "misspelled test",
+			script: `if headr :contains "subject" "x" { discard; }`,
+			err:    `unknown test "headr"`,
+		},
+		{
+			name:   "unknown test in test list",
+			script: `if anyof (true, sizes :over 1M) { discard; }`,
+			err:    `unknown test "sizes"`,
+		},
+		{
+			name:   "test used as command",
+			script: `header "subject" "x";`,
+			err:    `unknown command "header"`,
+		},
+		{
+			name:   "command used as test",
+			script: `require "fileinto"; if fileinto "Junk" { stop; }`,
+			err:    `unknown test "fileinto"`,
+		},
+		{
+			name:   "unexpected character",
+			script: `keep; @`,
+			err:    `unexpected character '@'`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := parseSieve(test.script)
+			switch {
+			case test.err == "" && err != nil:
+				t.Errorf("parseSieve() = %s, want no error", err)
+			case test.err != "" && err == nil:
+				t.Errorf("parseSieve() = nil, want an error containing %q", test.err)
+			case test.err != "" && !strings.Contains(err.Error(), test.err):
+				t.Errorf("parseSieve() = %s, want an error containing %q", err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+// StringOneOfValidator checks a string, or every element of a list or set of
+// strings, against the allowed Values.
+type StringOneOfValidator struct {
+	Values []string
+}
+
+func (v StringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.Values, ", "))
+}
+
+func (v StringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: `%s`", strings.Join(v.Values, "`, `"))
+}
+
+func (v StringOneOfValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var elems []attr.Value
+	switch value := req.AttributeConfig.(type) {
+	case types.List:
+		elems = value.Elems
+	case types.Set:
+		elems = value.Elems
+	default:
+		elems = []attr.Value{req.AttributeConfig}
+	}
+
+	for _, elem := range elems {
+		var value types.String
+		diags := tfsdk.ValueAs(ctx, elem, &value)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		if value.Unknown || value.Null || v.allowed(value.Value) {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Value",
+			fmt.Sprintf("Value must be one of %s, got: %q.", strings.Join(v.Values, ", "), value.Value),
+		)
+	}
+}
+
+func (v StringOneOfValidator) allowed(value string) bool {
+	for _, allowed := range v.Values {
+		if value == allowed {
+			return true
+		}
+	}
+
+	return false
+}